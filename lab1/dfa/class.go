@@ -0,0 +1,201 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// границы суррогатных половин UTF-16
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// RuneRange — диапазон рун от Lo до Hi включительно
+type RuneRange struct {
+	Lo, Hi rune
+}
+
+// String возвращает запись диапазона в виде a-z или одной руны; служебные и непечатаемые руны экранируются
+func (r RuneRange) String() string {
+	var b strings.Builder
+	r.write(&b)
+	return b.String()
+}
+
+// write записывает диапазон в b в синтаксисе класса символов пакета regexp
+func (r RuneRange) write(b *strings.Builder) {
+	writeClassRune(b, r.Lo)
+	if r.Hi > r.Lo+1 {
+		b.WriteByte('-')
+	}
+	if r.Hi > r.Lo {
+		writeClassRune(b, r.Hi)
+	}
+}
+
+// Class — класс символов: упорядоченный список непересекающихся и не соприкасающихся диапазонов рун
+type Class []RuneRange
+
+// Предопределённые классы символов
+var (
+	ClassDigit    = NewClass(RuneRange{'0', '9'})                                                                // \d
+	ClassWord     = NewClass(RuneRange{'0', '9'}, RuneRange{'A', 'Z'}, RuneRange{'_', '_'}, RuneRange{'a', 'z'}) // \w
+	ClassSpace    = NewClass(RuneRange{'\t', '\n'}, RuneRange{'\f', '\r'}, RuneRange{' ', ' '})                  // \s
+	ClassLatin    = NewClass(RuneRange{'A', 'Z'}, RuneRange{'a', 'z'})                                           // латинские буквы
+	ClassCyrillic = NewClass(RuneRange{'Ё', 'Ё'}, RuneRange{'А', 'я'}, RuneRange{'ё', 'ё'})                      // русские буквы
+	ClassAny      = NewClass(RuneRange{0, unicode.MaxRune})                                                      // любая руна
+)
+
+// NewClass создает класс символов из произвольных диапазонов: пустые диапазоны отбрасываются,
+// пересекающиеся и соседние объединяются, результат упорядочивается. Руны вне Unicode
+// и суррогатные половины не встречаются в строках Go и в класс не включаются
+func NewClass(ranges ...RuneRange) Class {
+	var c Class
+	add := func(lo, hi rune) {
+		if lo <= hi {
+			c = append(c, RuneRange{lo, hi})
+		}
+	}
+	for _, r := range ranges {
+		lo, hi := max(r.Lo, 0), min(r.Hi, unicode.MaxRune)
+		add(lo, min(hi, surrogateMin-1))
+		add(max(lo, surrogateMax+1), hi)
+	}
+	sort.Slice(c, func(i, j int) bool { return c[i].Lo < c[j].Lo })
+	out := c[:0]
+	for _, r := range c {
+		if n := len(out); n > 0 && r.Lo <= out[n-1].Hi+1 {
+			if r.Hi > out[n-1].Hi {
+				out[n-1].Hi = r.Hi
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// Contains возвращает true, если руна принадлежит классу
+func (c Class) Contains(r rune) bool {
+	i := sort.Search(len(c), func(i int) bool { return c[i].Hi >= r })
+	return i < len(c) && c[i].Lo <= r
+}
+
+// Union возвращает объединение классов
+func (c Class) Union(other Class) Class {
+	return NewClass(append(append(Class(nil), c...), other...)...)
+}
+
+// String возвращает запись класса в синтаксисе пакета regexp, например [0-9a-z]
+func (c Class) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for _, r := range c {
+		r.write(&b)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// rangeEdge — переход по диапазону рун
+type rangeEdge struct {
+	lo, hi rune
+	to     *State
+}
+
+// rangeTarget возвращает состояние, в которое ведёт переход по диапазону, содержащему руну, или nil
+func (d *DFA) rangeTarget(from *State, r rune) *State {
+	edges := d.ranges[from]
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].hi >= r })
+	if i < len(edges) && edges[i].lo <= r {
+		return edges[i].to
+	}
+	return nil
+}
+
+// cutRange удаляет из упорядоченного списка переходов все руны диапазона [lo, hi]
+func cutRange(edges []rangeEdge, lo, hi rune) []rangeEdge {
+	var out []rangeEdge
+	for _, e := range edges {
+		if e.hi < lo || e.lo > hi {
+			out = append(out, e)
+			continue
+		}
+		if e.lo < lo {
+			out = append(out, rangeEdge{e.lo, lo - 1, e.to})
+		}
+		if e.hi > hi {
+			out = append(out, rangeEdge{hi + 1, e.hi, e.to})
+		}
+	}
+	return out
+}
+
+// insertRange добавляет переход по диапазону [lo, hi], заменяя прежние переходы по его рунам
+func insertRange(edges []rangeEdge, lo, hi rune, to *State) []rangeEdge {
+	edges = append(cutRange(edges, lo, hi), rangeEdge{lo, hi, to})
+	sort.Slice(edges, func(i, j int) bool { return edges[i].lo < edges[j].lo })
+	out := edges[:0]
+	for _, e := range edges {
+		if n := len(out); n > 0 && out[n-1].to == e.to && out[n-1].hi+1 == e.lo {
+			out[n-1].hi = e.hi // слить соседние диапазоны с одним состоянием перехода
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// SetRangeTransition устанавливает переход из заданного исходного состояния в заданное конечное состояние
+// по любой руне из диапазона от lo до hi включительно. Прежние переходы по рунам диапазона заменяются.
+// Переход по букве алфавита, имя которой совпадает с руной, имеет приоритет над переходом по диапазону
+func (d *DFA) SetRangeTransition(fromName, toName string, lo, hi rune) error {
+	return d.SetClassTransition(fromName, toName, NewClass(RuneRange{lo, hi}))
+}
+
+// SetClassTransition устанавливает переход из заданного исходного состояния в заданное конечное состояние
+// по любой руне класса. Прежние переходы по рунам класса заменяются.
+// Возвращает ошибку ErrUnknownState, если какое-то из состояний не принадлежит ДКА
+func (d *DFA) SetClassTransition(fromName, toName string, c Class) error {
+	from := d.FindStateByName(fromName)
+	if from == nil {
+		return &StateError{Op: "SetClassTransition", Name: fromName, Err: ErrUnknownState}
+	}
+	to := d.FindStateByName(toName)
+	if to == nil {
+		return &StateError{Op: "SetClassTransition", Name: toName, Err: ErrUnknownState}
+	}
+	edges := d.ranges[from]
+	for _, r := range NewClass(c...) {
+		edges = insertRange(edges, r.Lo, r.Hi, to)
+	}
+	d.ranges[from] = edges
+	return nil
+}
+
+// RemoveClassTransition удаляет переходы из заданного состояния по всем рунам класса
+// Возвращает ошибку ErrUnknownState, если состояние не принадлежит ДКА
+func (d *DFA) RemoveClassTransition(fromName string, c Class) error {
+	from := d.FindStateByName(fromName)
+	if from == nil {
+		return &StateError{Op: "RemoveClassTransition", Name: fromName, Err: ErrUnknownState}
+	}
+	edges := d.ranges[from]
+	for _, r := range NewClass(c...) {
+		edges = cutRange(edges, r.Lo, r.Hi)
+	}
+	if len(edges) == 0 {
+		delete(d.ranges, from)
+	} else {
+		d.ranges[from] = edges
+	}
+	return nil
+}
+
+// spanName возвращает запись диапазона рун для сообщений о структуре автомата
+func spanName(r RuneRange) string {
+	return fmt.Sprint(Class{r})
+}
@@ -0,0 +1,243 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+	"unicode"
+)
+
+// identifierDFA строит автомат идентификаторов: русская или латинская буква, затем буквы и цифры.
+// Буква "ё" задана отдельным символом алфавита и переводит в заключительное состояние s2
+func identifierDFA() *dfa.DFA {
+	automata := dfa.NewDFA(3)
+	letters := dfa.ClassCyrillic.Union(dfa.ClassLatin)
+	automata.SetClassTransition("s0", "s1", letters)
+	automata.SetClassTransition("s1", "s1", letters.Union(dfa.ClassDigit))
+	automata.AddLetter("ё")
+	automata.SetTransition("s1", "s2", "ё")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	automata.SetEndState("s2")
+	return automata
+}
+
+func TestNewClass(t *testing.T) {
+	c := dfa.NewClass(dfa.RuneRange{Lo: 'x', Hi: 'z'}, dfa.RuneRange{Lo: 'a', Hi: 'c'},
+		dfa.RuneRange{Lo: 'b', Hi: 'f'}, dfa.RuneRange{Lo: 'g', Hi: 'g'}, dfa.RuneRange{Lo: '9', Hi: '0'})
+	want := dfa.Class{{Lo: 'a', Hi: 'g'}, {Lo: 'x', Hi: 'z'}}
+	if !slices.Equal(c, want) {
+		t.Fatalf("NewClass = %v, ожидался %v", c, want)
+	}
+	if got := c.String(); got != "[a-gx-z]" {
+		t.Errorf("String() = %q", got)
+	}
+	for r, ok := range map[rune]bool{'a': true, 'g': true, 'h': false, 'y': true, '{': false} {
+		if c.Contains(r) != ok {
+			t.Errorf("Contains(%q) = %v", r, !ok)
+		}
+	}
+	if dfa.ClassAny.Contains(0xD800) || !dfa.ClassAny.Contains(unicode.MaxRune) {
+		t.Errorf("ClassAny должен содержать все руны, кроме суррогатных")
+	}
+}
+
+func TestPredefinedClasses(t *testing.T) {
+	for _, c := range []struct {
+		class dfa.Class
+		in    string
+		out   string
+	}{
+		{dfa.ClassDigit, "0123456789", "a٣ "},
+		{dfa.ClassWord, "azAZ09_", "-ыЁ "},
+		{dfa.ClassSpace, " \t\n\r\f", "a_\v\u00a0"},
+		{dfa.ClassCyrillic, "абвгдеёжзийклмнопрстуфхцчшщъыьэюяЁЯ", "aєЀ"},
+	} {
+		for _, r := range c.in {
+			if !c.class.Contains(r) {
+				t.Errorf("%v должен содержать %q", c.class, r)
+			}
+		}
+		for _, r := range c.out {
+			if c.class.Contains(r) {
+				t.Errorf("%v не должен содержать %q", c.class, r)
+			}
+		}
+	}
+}
+
+func TestClassTransitions(t *testing.T) {
+	automata := identifierDFA()
+	session := automata.NewSession()
+	for s, ok := range map[string]bool{
+		"x": true, "переменная2": true, "Ёлка": true, "ёж": true, "жёж": false, "абвё": true,
+		"9x": false, "": false, "идент_": false, "ключ🔑": false,
+	} {
+		if automata.Accepts(s) != ok {
+			t.Errorf("Accepts(%q) = %v", s, !ok)
+		}
+		if session.Accepts(s) != ok {
+			t.Errorf("Session.Accepts(%q) = %v", s, !ok)
+		}
+	}
+	// переход по символу "ё" имеет приоритет над диапазоном
+	session.Reset()
+	session.Step("ж")
+	if got := session.Step("ё"); got == nil || got.String() != "s2" {
+		t.Errorf("переход по ё ведёт в %v, ожидалось s2", got)
+	}
+
+	// TransitionE принимает только символы алфавита: руну вне алфавита читает Session.Step
+	automata.ResetCurrentState()
+	if _, err := automata.TransitionE(dfa.NewLetter("ж")); !errors.Is(err, dfa.ErrUnknownLetter) {
+		t.Errorf("TransitionE по символу вне алфавита: %v", err)
+	}
+	if got, err := automata.TransitionE(automata.FindLetterByName("ё")); err != nil || got.String() != "s1" {
+		t.Errorf("TransitionE по букве ё через диапазон: %v, %v", got, err)
+	}
+}
+
+func TestClassTransitionErrors(t *testing.T) {
+	automata := dfa.NewDFA(1)
+	if err := automata.SetRangeTransition("s0", "s9", 'a', 'z'); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("SetRangeTransition в несуществующее состояние: %v", err)
+	}
+	if err := automata.RemoveClassTransition("s9", dfa.ClassDigit); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("RemoveClassTransition из несуществующего состояния: %v", err)
+	}
+}
+
+func TestOverwriteAndRemoveRanges(t *testing.T) {
+	automata := dfa.NewDFA(3)
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	automata.SetRangeTransition("s0", "s1", 'a', 'z')
+	automata.SetRangeTransition("s0", "s2", 'k', 'm')
+	automata.RemoveClassTransition("s0", dfa.NewClass(dfa.RuneRange{Lo: 'x', Hi: 'x'}))
+	for s, ok := range map[string]bool{"a": true, "j": true, "k": false, "m": false, "n": true, "x": false, "z": true} {
+		if automata.Accepts(s) != ok {
+			t.Errorf("Accepts(%q) = %v", s, !ok)
+		}
+	}
+	automata.RemoveState(automata.FindStateByName("s1"))
+	automata.SetEndState("s2")
+	if automata.Accepts("a") || !automata.Accepts("l") {
+		t.Errorf("после удаления s1 переходы в него должны исчезнуть")
+	}
+}
+
+func TestClassMatcherAgrees(t *testing.T) {
+	automata := identifierDFA()
+	m := automata.Compile()
+	rng := rand.New(rand.NewSource(17))
+	alphabet := []rune("aZ0_ёЁжЯ€ \x7f")
+	for k := 0; k < 2000; k++ {
+		word := make([]rune, rng.Intn(6))
+		for i := range word {
+			word[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		if s := string(word); m.Accepts(s) != automata.Accepts(s) {
+			t.Fatalf("Matcher расходится с ДКА на %q", s)
+		}
+	}
+}
+
+func TestClassAlgorithms(t *testing.T) {
+	automata := identifierDFA()
+
+	// ё в s1 ведёт в s2, из которого нет переходов: минимальный автомат сохраняет три состояния
+	if n := automata.Minimize().Compile().NumStates(); n != 3 {
+		t.Errorf("Minimize: %d состояний, ожидалось 3", n)
+	}
+	if ok, word := dfa.Equivalent(automata, automata.Minimize()); !ok {
+		t.Errorf("Minimize меняет язык, контрпример %q", chainString(word))
+	}
+
+	// без отдельной буквы ё языки различаются на цепочке из трёх символов
+	plain := dfa.NewDFA(2)
+	letters := dfa.ClassCyrillic.Union(dfa.ClassLatin)
+	plain.SetClassTransition("s0", "s1", letters)
+	plain.SetClassTransition("s1", "s1", letters.Union(dfa.ClassDigit))
+	plain.SetStartState("s0")
+	plain.SetEndState("s1")
+	ok, word := dfa.Equivalent(automata, plain)
+	if ok || len(word) != 3 || automata.CheckChain(word) == plain.CheckChain(word) {
+		t.Errorf("Equivalent: контрпример %q", chainString(word))
+	}
+
+	complement := automata.Complement()
+	for _, s := range []string{"x", "ж1", "", "1", "жёж", "абвё"} {
+		if complement.Accepts(s) == automata.Accepts(s) {
+			t.Errorf("Complement: совпадение на %q", s)
+		}
+	}
+
+	if got, want := automata.CountWords(1).Int64(), int64(26*2+66); got != want {
+		t.Errorf("CountWords(1) = %d, ожидалось %d", got, want)
+	}
+	if got, want := automata.CountWords(2).Int64(), int64(26*2+66)*(26*2+66+10); got != want {
+		t.Errorf("CountWords(2) = %d, ожидалось %d", got, want)
+	}
+}
+
+func TestClassWordsAndSample(t *testing.T) {
+	automata := dfa.NewDFA(2)
+	automata.SetRangeTransition("s0", "s1", '0', '2')
+	automata.AddLetter("1x") // многосимвольная буква стоит между "1" и "2"
+	automata.SetTransition("s0", "s1", "1x")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+
+	var got []string
+	for w := range automata.Words(-1) {
+		got = append(got, w)
+	}
+	if want := []string{"0", "1", "1x", "2"}; !slices.Equal(got, want) {
+		t.Errorf("Words = %q, ожидалось %q", got, want)
+	}
+	page, next, err := automata.WordsPage("", 2, -1)
+	if err != nil || !slices.Equal(page, []string{"0", "1"}) {
+		t.Fatalf("WordsPage = %q, %v", page, err)
+	}
+	if page, _, err = automata.WordsPage(next, 5, -1); err != nil || !slices.Equal(page, []string{"1x", "2"}) {
+		t.Errorf("вторая страница %q, %v", page, err)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	seen := map[string]int{}
+	for k := 0; k < 4000; k++ {
+		w, ok := automata.Sample(rng, 1)
+		if !ok || !automata.Accepts(w) && w != "1x" {
+			t.Fatalf("Sample вернул %q", w)
+		}
+		seen[w]++
+	}
+	for _, w := range []string{"0", "1", "1x", "2"} {
+		if seen[w] < 800 || seen[w] > 1200 {
+			t.Errorf("%q выпала %d раз из 4000", w, seen[w])
+		}
+	}
+}
+
+func TestClassRegexAndValidate(t *testing.T) {
+	// внутри цикла ё не встречается: переход по букве ё ведёт в s2
+	if got, want := identifierDFA().ToRegex(), "[A-Za-zЁА-яё][0-9A-Za-zЁА-я]*ё?"; got != want {
+		t.Errorf("ToRegex = %q, ожидалось %q", got, want)
+	}
+
+	automata := dfa.NewDFA(2)
+	automata.SetRangeTransition("s0", "s1", 'a', 'c')
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	var missing []string
+	for _, d := range automata.Validate() {
+		if d.Kind == dfa.IncompleteState {
+			missing = append(missing, d.State+":"+d.Missing[0])
+		}
+	}
+	if want := []string{"s1:[a-c]"}; !slices.Equal(missing, want) {
+		t.Errorf("Validate: %q, ожидалось %q", missing, want)
+	}
+}
@@ -0,0 +1,51 @@
+package dfa
+
+// IsComplete возвращает true, если из каждого состояния ДКА определён переход по каждому символу алфавита
+// и по каждой руне, для которой хотя бы в одном состоянии есть переход по диапазону
+func (d *DFA) IsComplete() bool {
+	for _, row := range d.table().delta {
+		for _, to := range row {
+			if to < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Complete дополняет ДКА незаключительным состоянием-стоком: все недостающие переходы
+// ведут в сток, а сток переходит сам в себя по каждому символу алфавита.
+// Недостающие переходы по рунам диапазонов также ведут в сток.
+// Возвращает добавленное состояние или nil, если ДКА уже полон
+func (d *DFA) Complete() *State {
+	if d.IsComplete() {
+		return nil
+	}
+	t := d.table()
+	sink := d.AddState(t.freeName("sink"), false)
+	for s := range d.states {
+		for _, sym := range t.syms {
+			switch {
+			case sym.isSpan:
+				if d.rangeTarget(s, sym.span.Lo) == nil {
+					d.ranges[s] = insertRange(d.ranges[s], sym.span.Lo, sym.span.Hi, sink)
+				}
+			default:
+				if l := d.FindLetterByName(sym.name); d.next(s, l) == nil {
+					d.trans[s][l] = sink
+				}
+			}
+		}
+	}
+	return sink
+}
+
+// Complement возвращает новый ДКА, распознающий дополнение языка ДКА до множества
+// всех цепочек над его алфавитом и рунами его диапазонов. Исходный автомат не изменяется
+func (d *DFA) Complement() *DFA {
+	t := d.table().complete()
+	for s := range t.term {
+		t.term[s] = !t.term[s]
+	}
+	return t.build()
+}
@@ -0,0 +1,60 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.AddState("sink", false)
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+
+	if automata.IsComplete() {
+		t.Fatalf("автомат не должен быть полным")
+	}
+	sink := automata.Complete()
+	if sink == nil || sink.String() != "sink1" || sink.IsTerminal() {
+		t.Fatalf("ожидалось незаключительное состояние-сток sink1, получено %v", sink)
+	}
+	if !automata.IsComplete() {
+		t.Errorf("после Complete автомат должен быть полным")
+	}
+	if automata.Complete() != nil {
+		t.Errorf("повторный Complete не должен добавлять состояний")
+	}
+
+	automata.ResetCurrentState()
+	if automata.Transition(automata.FindLetterByName("b")) != sink {
+		t.Errorf("недостающий переход должен вести в сток")
+	}
+	if !automata.Accepts("a") || automata.Accepts("ab") {
+		t.Errorf("Complete не должен менять язык автомата")
+	}
+}
+
+func TestComplement(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	blacklist := email.Complement()
+
+	for _, s := range []string{"a@b.ru", "vladimirov_d1ma@mail.ru", "a@b.com"} {
+		if blacklist.Accepts(s) {
+			t.Errorf("дополнение не должно принимать %q", s)
+		}
+	}
+	for _, s := range []string{"", "xd", "a@b.co", "@@"} {
+		if !blacklist.Accepts(s) {
+			t.Errorf("дополнение должно принимать %q", s)
+		}
+	}
+	if blacklist.Accepts("Z") {
+		t.Errorf("символы вне алфавита не принадлежат дополнению")
+	}
+	if email.FindStateByName("sink") != nil {
+		t.Errorf("Complement не должен изменять исходный автомат")
+	}
+}
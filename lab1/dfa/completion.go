@@ -0,0 +1,151 @@
+package dfa
+
+import (
+	"container/heap"
+	"unicode/utf8"
+)
+
+// Complete возвращает не более limit слов словаря, начинающихся с prefix, вместе с самим префиксом.
+// Слова словаря без весов перечисляются в лексикографическом порядке. Слова словаря с весами
+// перечисляются по убыванию веса, слова с равным весом — лексикографически; поиск идёт
+// от лучших продолжений, оценивая каждое состояние наибольшим весом слова, читаемого из него,
+// поэтому просматривается лишь часть словаря, а не все слова с префиксом
+func (d *Dict) Complete(prefix string, limit int) []string {
+	s := d.walk(prefix)
+	if s < 0 || limit <= 0 {
+		return nil
+	}
+	if d.weights == nil {
+		return d.lexicographic(s, prefix, limit)
+	}
+
+	var out []string
+	q := &completionQueue{{text: prefix, state: s, key: d.best[s]}}
+	for q.Len() > 0 && len(out) < limit {
+		c := heap.Pop(q).(completion)
+		if c.word {
+			out = append(out, c.text)
+			continue
+		}
+		if d.final[c.state] {
+			heap.Push(q, completion{text: c.text, state: c.state, key: d.weights[c.state], word: true})
+		}
+		for e := d.first[c.state]; e < d.first[c.state+1]; e++ {
+			to := d.targets[e]
+			heap.Push(q, completion{text: c.text + string(d.labels[e]), state: to, key: d.best[to]})
+		}
+	}
+	return out
+}
+
+// lexicographic возвращает не более limit слов, читаемых из состояния s, в лексикографическом порядке,
+// дописывая их к prefix
+func (d *Dict) lexicographic(s int32, prefix string, limit int) []string {
+	var out []string
+	buf := []byte(prefix)
+	var rec func(s int32)
+	rec = func(s int32) {
+		if d.final[s] {
+			out = append(out, string(buf))
+		}
+		n := len(buf)
+		for e := d.first[s]; e < d.first[s+1] && len(out) < limit; e++ {
+			buf = utf8.AppendRune(buf[:n], d.labels[e])
+			rec(d.targets[e])
+		}
+		buf = buf[:n]
+	}
+	rec(s)
+	return out
+}
+
+// completion — элемент очереди поиска продолжений: слово или все продолжения строки text
+type completion struct {
+	text  string
+	state int32
+	key   int64 // вес слова или наибольший вес продолжения
+	word  bool  // элемент — слово text
+}
+
+// completionQueue — очередь с приоритетом: сначала больший ключ, затем меньшая строка,
+// затем слово раньше своих продолжений
+type completionQueue []completion
+
+func (q completionQueue) Len() int { return len(q) }
+func (q completionQueue) Less(i, j int) bool {
+	if q[i].key != q[j].key {
+		return q[i].key > q[j].key
+	}
+	if q[i].text != q[j].text {
+		return q[i].text < q[j].text
+	}
+	return q[i].word && !q[j].word
+}
+func (q completionQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *completionQueue) Push(x any)   { *q = append(*q, x.(completion)) }
+func (q *completionQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// Completions возвращает не более limit цепочек языка ДКА, начинающихся с prefix, в лексикографическом
+// порядке. Префикс читается по одной руне, как в Accepts. Так ДКА, по которому проверяется ввод,
+// сам подсказывает продолжения; для больших словарей и слов с весами есть Dict.Complete.
+// Второе значение равно false, если продолжений префикса бесконечно много: лексикографический
+// порядок тогда может не иметь первого элемента, и результат равен nil
+func (d *DFA) Completions(prefix string, limit int) ([]string, bool) {
+	t := d.table()
+	s := t.start
+	for _, r := range prefix {
+		if s < 0 {
+			break
+		}
+		l, ok := t.lookup(string(r))
+		if !ok {
+			return nil, true
+		}
+		s = t.delta[s][l]
+	}
+	if s < 0 {
+		return nil, true
+	}
+	t.start = s
+	t = t.trim()
+	if !t.live()[t.start] {
+		return nil, true // trim сохраняет тупиковое начальное состояние, например сток, вместе с его петлями
+	}
+	if _, ok := t.acyclicOrder(); !ok {
+		return nil, false
+	}
+	if limit <= 0 {
+		return nil, true
+	}
+
+	var out []string
+	var word []pick
+	var rec func(s int)
+	rec = func(s int) {
+		if t.term[s] {
+			out = append(out, prefix+t.spell(word))
+		}
+		for l := 0; l < len(t.syms) && len(out) < limit; l++ {
+			to := t.delta[s][l]
+			if to < 0 {
+				continue
+			}
+			first, last := rune(0), rune(0)
+			if sym := t.syms[l]; sym.isSpan {
+				first, last = sym.span.Lo, sym.span.Hi
+			}
+			for r := first; r <= last && len(out) < limit; r++ {
+				word = append(word, pick{l, r})
+				rec(to)
+				word = word[:len(word)-1]
+			}
+		}
+	}
+	rec(t.start)
+	return out, true
+}
@@ -0,0 +1,128 @@
+package dfa_test
+
+import (
+	"bytes"
+	"dfa"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestDictComplete(t *testing.T) {
+	words := []string{"кот", "котёнок", "котик", "коты", "крот", "рот"}
+	d, err := dfa.FromSortedWords(slices.Values(slices.Sorted(slices.Values(words))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{"кот", 10, []string{"кот", "котик", "коты", "котёнок"}},
+		{"к", 2, []string{"кот", "котик"}},
+		{"", 10, []string{"кот", "котик", "коты", "котёнок", "крот", "рот"}},
+		{"котё", 10, []string{"котёнок"}},
+		{"ко", 0, nil},
+		{"я", 10, nil},
+	} {
+		if got := d.Complete(c.prefix, c.limit); !slices.Equal(got, c.want) {
+			t.Errorf("Complete(%q, %d) = %q, ожидалось %q", c.prefix, c.limit, got, c.want)
+		}
+	}
+}
+
+func TestDictCompleteWeighted(t *testing.T) {
+	weights := map[string]int64{"кот": 5, "котёнок": 9, "котик": 5, "коты": 1, "крот": 7, "рот": 8}
+	d, err := dfa.FromSortedWeightedWords(func(yield func(string, int64) bool) {
+		for _, w := range slices.Sorted(maps.Keys(weights)) {
+			if !yield(w, weights[w]) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{"к", 10, []string{"котёнок", "крот", "кот", "котик", "коты"}},
+		{"", 3, []string{"котёнок", "рот", "крот"}},
+		{"кот", 2, []string{"котёнок", "кот"}},
+	} {
+		if got := d.Complete(c.prefix, c.limit); !slices.Equal(got, c.want) {
+			t.Errorf("Complete(%q, %d) = %q, ожидалось %q", c.prefix, c.limit, got, c.want)
+		}
+	}
+	if w, ok := d.Weight("крот"); !ok || w != 7 {
+		t.Errorf("Weight(крот) = %d, %v", w, ok)
+	}
+	if _, ok := d.Weight("кро"); ok {
+		t.Errorf("Weight(кро) нашёл слово")
+	}
+
+	// веса сохраняются при записи и не дают объединить состояния "кот" и "рот"
+	plain, _ := dfa.FromSortedWords(slices.Values(slices.Sorted(maps.Keys(weights))))
+	if d.NumStates() <= plain.NumStates() {
+		t.Errorf("словарь с весами: %d состояний, без весов %d", d.NumStates(), plain.NumStates())
+	}
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	r, err := dfa.ReadDict(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Complete("", 3); !slices.Equal(got, []string{"котёнок", "рот", "крот"}) {
+		t.Errorf("после чтения Complete = %q", got)
+	}
+}
+
+func TestDFACompletions(t *testing.T) {
+	automata := dfa.NewDFA(3)
+	automata.AddLetter("x")
+	automata.SetRangeTransition("s0", "s1", 'a', 'c')
+	automata.SetTransition("s1", "s2", "x")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	automata.SetEndState("s2")
+	for _, c := range []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{"", 3, []string{"a", "ax", "b"}},
+		{"b", 10, []string{"b", "bx"}},
+		{"cx", 10, []string{"cx"}},
+		{"z", 10, nil},
+		{"bxx", 10, nil},
+	} {
+		got, ok := automata.Completions(c.prefix, c.limit)
+		if !ok || !slices.Equal(got, c.want) {
+			t.Errorf("Completions(%q, %d) = %q, %v; ожидалось %q", c.prefix, c.limit, got, ok, c.want)
+		}
+	}
+
+	// цикл по get даёт бесконечно много продолжений
+	protocol := protocolDFA()
+	if _, ok := protocol.Completions("", 10); ok {
+		t.Errorf("бесконечный язык должен давать false")
+	}
+	dict, _ := dfa.FromSortedWords(slices.Values([]string{"ab", "abc", "b"}))
+	if got, ok := dict.DFA().Completions("a", 10); !ok || !slices.Equal(got, []string{"ab", "abc"}) {
+		t.Errorf("Completions по ДКА словаря = %q, %v", got, ok)
+	}
+
+	// сток, добавленный Complete, тупиковый: его петли не делают продолжения бесконечными
+	words, _ := dfa.FromSortedWords(slices.Values([]string{"car", "cat", "dog"}))
+	complete := words.DFA()
+	complete.Complete()
+	if got, ok := complete.Completions("cd", 5); !ok || len(got) != 0 {
+		t.Errorf("Completions(cd) полного ДКА = %q, %v", got, ok)
+	}
+	if got, ok := complete.Completions("ca", 5); !ok || !slices.Equal(got, []string{"car", "cat"}) {
+		t.Errorf("Completions(ca) полного ДКА = %q, %v", got, ok)
+	}
+}
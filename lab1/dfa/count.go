@@ -0,0 +1,135 @@
+package dfa
+
+import "math/big"
+
+// CountWords возвращает число цепочек языка ДКА длиной ровно n символов
+func (d *DFA) CountWords(n int) *big.Int {
+	t := d.table().trim()
+	if n < 0 || t.start < 0 {
+		return new(big.Int)
+	}
+	return t.counts(n)[n][t.start]
+}
+
+// counts возвращает таблицу c, в которой c[r][s] — число путей ровно из r символов
+// из состояния s в заключительное состояние, для r от 0 до n
+func (t *table) counts(n int) [][]*big.Int {
+	c := make([][]*big.Int, n+1)
+	c[0] = make([]*big.Int, len(t.names))
+	for s, term := range t.term {
+		c[0][s] = new(big.Int)
+		if term {
+			c[0][s].SetInt64(1)
+		}
+	}
+	for r := 1; r <= n; r++ {
+		c[r] = make([]*big.Int, len(t.names))
+		for s, row := range t.delta {
+			sum := new(big.Int)
+			for l, to := range row {
+				if to >= 0 {
+					sum.Add(sum, t.weigh(l, c[r-1][to]))
+				}
+			}
+			c[r][s] = sum
+		}
+	}
+	return c
+}
+
+// weigh возвращает число цепочек, начинающихся символом l и продолжающихся одним из n способов:
+// для диапазона рун n умножается на число рун
+func (t *table) weigh(l int, n *big.Int) *big.Int {
+	if size := t.syms[l].size(); size != 1 {
+		return new(big.Int).Mul(n, big.NewInt(size))
+	}
+	return n
+}
+
+// IsEmpty возвращает true, если язык ДКА пуст
+func (d *DFA) IsEmpty() bool {
+	t := d.table()
+	return t.start < 0 || !t.live()[t.start]
+}
+
+// IsFinite возвращает true, если язык ДКА конечен, то есть среди состояний,
+// достижимых из начального и ведущих к заключительному, нет циклов
+func (d *DFA) IsFinite() bool {
+	if d.IsEmpty() {
+		return true // trim сохраняет тупиковое начальное состояние вместе с его петлями
+	}
+	_, ok := d.table().trim().acyclicOrder()
+	return ok
+}
+
+// acyclicOrder возвращает состояния таблицы в обратном топологическом порядке
+// (каждое состояние после всех, в которые из него есть переходы).
+// Второе значение равно false, если граф переходов содержит цикл
+func (t *table) acyclicOrder() ([]int, bool) {
+	const (
+		white = iota // состояние ещё не посещено
+		grey         // состояние на стеке обхода
+		black        // обход из состояния завершён
+	)
+	color := make([]int, len(t.names))
+	order := make([]int, 0, len(t.names))
+
+	type frame struct{ s, l int }
+	for root := range t.names {
+		if color[root] != white {
+			continue
+		}
+		color[root] = grey
+		stack := []frame{{root, 0}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.l == len(t.syms) {
+				color[top.s] = black
+				order = append(order, top.s)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			to := t.delta[top.s][top.l]
+			top.l++
+			if to < 0 {
+				continue
+			}
+			switch color[to] {
+			case grey:
+				return nil, false
+			case white:
+				color[to] = grey
+				stack = append(stack, frame{to, 0})
+			}
+		}
+	}
+	return order, true
+}
+
+// Size возвращает число цепочек языка ДКА.
+// Второе значение равно false, если язык бесконечен; первое значение тогда равно nil
+func (d *DFA) Size() (*big.Int, bool) {
+	if d.IsEmpty() {
+		return new(big.Int), true
+	}
+	t := d.table().trim()
+	order, ok := t.acyclicOrder()
+	if !ok {
+		return nil, false
+	}
+
+	// size[s] — число цепочек, ведущих из s в заключительное состояние
+	size := make([]*big.Int, len(t.names))
+	for _, s := range order {
+		size[s] = new(big.Int)
+		if t.term[s] {
+			size[s].SetInt64(1)
+		}
+		for l, to := range t.delta[s] {
+			if to >= 0 {
+				size[s].Add(size[s], t.weigh(l, size[to]))
+			}
+		}
+	}
+	return size[t.start], true
+}
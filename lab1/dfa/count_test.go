@@ -0,0 +1,74 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+func TestCountWords(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	for n, want := range map[int]int64{0: 0, 4: 0, 5: 26, 6: 26 + 26*38 + 26*38} {
+		if got := email.CountWords(n); got.Int64() != want {
+			t.Errorf("CountWords(%d) = %v, ожидалось %d", n, got, want)
+		}
+	}
+
+	brute := int64(0)
+	for w := range email.Words(6) {
+		if len(w) == 6 {
+			brute++
+		}
+	}
+	if got := email.CountWords(6).Int64(); got != brute {
+		t.Errorf("CountWords(6) = %d, перебор дал %d", got, brute)
+	}
+
+	// число цепочек длины 64 над алфавитом из 40 символов не помещается в int64
+	all := maxLenDFA("abcdefghijklmnopqrstuvwxyz0123456789.@_-", 64)
+	if got := all.CountWords(64); got.BitLen() < 300 {
+		t.Errorf("CountWords(64) = %v", got)
+	}
+}
+
+func TestFiniteness(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	if email.IsEmpty() || email.IsFinite() {
+		t.Errorf("язык адресов непуст и бесконечен")
+	}
+	if size, ok := email.Size(); ok || size != nil {
+		t.Errorf("Size() бесконечного языка = %v, %v", size, ok)
+	}
+
+	short := maxLenDFA("ab", 3)
+	if !short.IsFinite() {
+		t.Errorf("язык цепочек длиной не более 3 конечен")
+	}
+	if size, ok := short.Size(); !ok || size.Int64() != 15 {
+		t.Errorf("Size() = %v, %v, ожидалось 15", size, ok)
+	}
+
+	// цикл в тупиковой части автомата не делает язык бесконечным
+	short.AddState("loop", false)
+	short.SetTransition("s3", "loop", "a")
+	short.SetTransition("loop", "loop", "a")
+	if !short.IsFinite() {
+		t.Errorf("цикл через тупиковое состояние не должен влиять на конечность")
+	}
+
+	empty := dfa.NewDFA(1)
+	empty.SetStartState("s0")
+	if !empty.IsEmpty() || !empty.IsFinite() {
+		t.Errorf("язык без заключительных состояний пуст и конечен")
+	}
+	if size, ok := empty.Size(); !ok || size.Sign() != 0 {
+		t.Errorf("Size() пустого языка = %v, %v", size, ok)
+	}
+
+	dead := deadLoopDFA()
+	if !dead.IsEmpty() || !dead.IsFinite() {
+		t.Errorf("язык с петлёй в тупиковом начальном состоянии пуст и конечен")
+	}
+	if size, ok := dead.Size(); !ok || size.Sign() != 0 {
+		t.Errorf("Size() пустого языка с петлёй = %v, %v", size, ok)
+	}
+}
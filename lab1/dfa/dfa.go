@@ -38,13 +38,17 @@ func (l *Letter) String() string {
 	return l.name
 }
 
-// DFA представляет детерминированный конечный автомат
+// DFA представляет детерминированный конечный автомат.
+// Методы, меняющие текущее состояние (Transition, ResetCurrentState), не безопасны
+// для одновременного использования; для параллельных проверок используйте Session
 type DFA struct {
-	states  map[*State]bool               // множество состояний ДКА
-	letters map[*Letter]bool              // множество символов алфавита ДКА
-	trans   map[*State]map[*Letter]*State // функция переходов ДКА
-	start   *State                        // начальное состояние ДКА
-	current *State                        // текущее состояние ДКА
+	states   map[*State]bool               // множество состояний ДКА
+	letters  map[*Letter]bool              // множество символов алфавита ДКА
+	trans    map[*State]map[*Letter]*State // функция переходов ДКА
+	ranges   map[*State][]rangeEdge        // переходы по диапазонам рун, упорядоченные по началу диапазона
+	start    *State                        // начальное состояние ДКА
+	current  *State                        // текущее состояние ДКА
+	observer Observer                      // наблюдатель за выполнением или nil
 }
 
 // NewDFA создает новый ДКА
@@ -53,6 +57,7 @@ func NewDFA(statesCount int) *DFA {
 		states:  make(map[*State]bool),
 		letters: make(map[*Letter]bool),
 		trans:   make(map[*State]map[*Letter]*State),
+		ranges:  make(map[*State][]rangeEdge),
 	}
 
 	if statesCount < 0 {
@@ -72,22 +77,33 @@ func NewDFA(statesCount int) *DFA {
 // AddState добавляет новое состояние в ДКА с заданным именем и флагом заключительности
 // Возвращает указатель на добавленное состояние или nil, если такое имя уже существует
 func (d *DFA) AddState(name string, term bool) *State {
-	for s := range d.states {
-		if s.name == name {
-			return nil // имя уже занято
-		}
+	state, _ := d.AddStateE(name, term)
+	return state
+}
+
+// AddStateE добавляет новое состояние в ДКА с заданным именем и флагом заключительности
+// Возвращает ошибку ErrDuplicateState, если такое имя уже существует
+func (d *DFA) AddStateE(name string, term bool) (*State, error) {
+	if d.FindStateByName(name) != nil {
+		return nil, &StateError{Op: "AddState", Name: name, Err: ErrDuplicateState} // имя уже занято
 	}
 	state := NewState(name, term)
 	d.states[state] = true
 	d.trans[state] = make(map[*Letter]*State)
-	return state
+	return state, nil
 }
 
 // RemoveState удаляет заданное состояние из ДКА и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого состояния не существует
 func (d *DFA) RemoveState(state *State) bool {
+	return d.RemoveStateE(state) == nil
+}
+
+// RemoveStateE удаляет заданное состояние из ДКА и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownState, если такого состояния не существует
+func (d *DFA) RemoveStateE(state *State) error {
 	if _, ok := d.states[state]; !ok {
-		return false // такого состояния нет в ДКА
+		return &StateError{Op: "RemoveState", Name: stateName(state), Err: ErrUnknownState} // такого состояния нет в ДКА
 	}
 	delete(d.states, state)
 	delete(d.trans, state)
@@ -98,39 +114,60 @@ func (d *DFA) RemoveState(state *State) bool {
 			}
 		}
 	}
+	delete(d.ranges, state)
+	for from, edges := range d.ranges {
+		kept := edges[:0]
+		for _, e := range edges {
+			if e.to != state {
+				kept = append(kept, e)
+			}
+		}
+		d.ranges[from] = kept
+	}
 	if d.start == state {
 		d.start = nil // обнулить начальное состояние, если оно удаляется
 	}
 	if d.current == state {
 		d.current = nil // обнулить текущее состояние, если оно удаляется
 	}
-	return true
+	return nil
 }
 
 // AddLetter добавляет новый символ в алфавит ДКА с заданным именем
 // Возвращает указатель на добавленный символ или nil, если такое имя уже существует
 func (d *DFA) AddLetter(name string) *Letter {
-	for l := range d.letters {
-		if l.name == name {
-			return nil // имя уже занято
-		}
+	letter, _ := d.AddLetterE(name)
+	return letter
+}
+
+// AddLetterE добавляет новый символ в алфавит ДКА с заданным именем
+// Возвращает ошибку ErrDuplicateLetter, если такое имя уже существует
+func (d *DFA) AddLetterE(name string) (*Letter, error) {
+	if d.FindLetterByName(name) != nil {
+		return nil, &LetterError{Op: "AddLetter", Name: name, Err: ErrDuplicateLetter} // имя уже занято
 	}
 	letter := NewLetter(name)
 	d.letters[letter] = true
-	return letter
+	return letter, nil
 }
 
 // RemoveLetter удаляет заданный символ из алфавита ДКА и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого символа не существует
 func (d *DFA) RemoveLetter(letter *Letter) bool {
+	return d.RemoveLetterE(letter) == nil
+}
+
+// RemoveLetterE удаляет заданный символ из алфавита ДКА и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownLetter, если такого символа не существует
+func (d *DFA) RemoveLetterE(letter *Letter) error {
 	if _, ok := d.letters[letter]; !ok {
-		return false // такого символа нет в алфавите ДКА
+		return &LetterError{Op: "RemoveLetter", Name: letterName(letter), Err: ErrUnknownLetter} // такого символа нет в алфавите ДКА
 	}
 	delete(d.letters, letter)
 	for _, m := range d.trans {
 		delete(m, letter) // удалить переход по удаляемому символу
 	}
-	return true
+	return nil
 }
 
 // FindLetterByName возвращает ссылку на букву алфавита по её имени
@@ -157,23 +194,35 @@ func (d *DFA) FindStateByName(name string) *State {
 // SetStartState устанавливает начальное состояние ДКА
 // Возвращает true, если состояние установлено успешно, или false, если заданное состояние не принадлежит ДКА
 func (d *DFA) SetStartState(name string) bool {
+	return d.SetStartStateE(name) == nil
+}
+
+// SetStartStateE устанавливает начальное состояние ДКА
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит ДКА
+func (d *DFA) SetStartStateE(name string) error {
 	state := d.FindStateByName(name)
 	if state == nil {
-		return false
+		return &StateError{Op: "SetStartState", Name: name, Err: ErrUnknownState}
 	}
 	d.start = state   // установить начальное состояние
 	d.current = state // установить текущее состояние равным начальному
-	return true
+	return nil
 }
 
 // SetEndState устанавливает состояние как конечное
 func (d *DFA) SetEndState(name string) bool {
+	return d.SetEndStateE(name) == nil
+}
+
+// SetEndStateE устанавливает состояние как конечное
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит ДКА
+func (d *DFA) SetEndStateE(name string) error {
 	s := d.FindStateByName(name)
 	if s == nil {
-		return false
+		return &StateError{Op: "SetEndState", Name: name, Err: ErrUnknownState}
 	}
 	s.term = true
-	return true
+	return nil
 }
 
 // GetStartState возвращает начальное состояние ДКА или nil, если оно не установлено
@@ -182,8 +231,9 @@ func (d *DFA) GetStartState() *State {
 }
 
 // IsEndState возвращает true если текущее состояние автомата заключительное
+// Если текущее состояние не установлено, возвращает false
 func (d *DFA) IsEndState() bool {
-	return d.current.IsTerminal()
+	return d.current != nil && d.current.IsTerminal()
 }
 
 // GetCurrentState возвращает текущее состояние ДКА или nil, если оно не установлено
@@ -199,78 +249,88 @@ func (d *DFA) ResetCurrentState() {
 // SetTransition устанавливает переход из заданного исходного состояния в заданное конечное состояние по заданному символу
 // Возвращает true, если переход установлен успешно, или false, если какой-то из параметров не принадлежит ДКА
 func (d *DFA) SetTransition(fromName, toName, letterBy string) bool {
-	by := d.FindLetterByName(letterBy)
+	return d.SetTransitionE(fromName, toName, letterBy) == nil
+}
+
+// SetTransitionE устанавливает переход из заданного исходного состояния в заданное конечное состояние по заданному символу
+// Возвращает ошибку ErrUnknownState или ErrUnknownLetter, если какой-то из параметров не принадлежит ДКА
+func (d *DFA) SetTransitionE(fromName, toName, letterBy string) error {
 	from := d.FindStateByName(fromName)
-	to := d.FindStateByName(toName)
-	if _, ok := d.states[from]; !ok {
-		return false // исходное состояние не принадлежит ДКА
+	if from == nil {
+		return &StateError{Op: "SetTransition", Name: fromName, Err: ErrUnknownState} // исходное состояние не принадлежит ДКА
 	}
-	if _, ok := d.states[to]; !ok {
-		return false // конечное состояние не принадлежит ДКА
+	to := d.FindStateByName(toName)
+	if to == nil {
+		return &StateError{Op: "SetTransition", Name: toName, Err: ErrUnknownState} // конечное состояние не принадлежит ДКА
 	}
-	if _, ok := d.letters[by]; !ok {
-		return false // символ не принадлежит алфавиту ДКА
+	by := d.FindLetterByName(letterBy)
+	if by == nil {
+		return &LetterError{Op: "SetTransition", Name: letterBy, Err: ErrUnknownLetter} // символ не принадлежит алфавиту ДКА
 	}
 	d.trans[from][by] = to // установить переход
-	return true
+	return nil
 }
 
 // RemoveTransition удаляет переход из заданного исходного состояния по заданному символу
 // Возвращает true, если переход удален успешно, или false, если какой-то из параметров не принадлежит ДКА или перехода не существует
 func (d *DFA) RemoveTransition(from *State, by *Letter) bool {
+	return d.RemoveTransitionE(from, by) == nil
+}
+
+// RemoveTransitionE удаляет переход из заданного исходного состояния по заданному символу
+// Возвращает ошибку ErrUnknownState, ErrUnknownLetter или ErrUnknownTransition
+func (d *DFA) RemoveTransitionE(from *State, by *Letter) error {
 	if _, ok := d.states[from]; !ok {
-		return false // исходное состояние не принадлежит ДКА
+		return &StateError{Op: "RemoveTransition", Name: stateName(from), Err: ErrUnknownState} // исходное состояние не принадлежит ДКА
 	}
 	if _, ok := d.letters[by]; !ok {
-		return false // символ не принадлежит алфавиту ДКА
+		return &LetterError{Op: "RemoveTransition", Name: letterName(by), Err: ErrUnknownLetter} // символ не принадлежит алфавиту ДКА
 	}
 	if _, ok := d.trans[from][by]; !ok {
-		return false // перехода не существует
+		return &TransitionError{Op: "RemoveTransition", From: from.name, Letter: by.name, Err: ErrUnknownTransition} // перехода не существует
 	}
 	delete(d.trans[from], by) // удалить переход
-	return true
+	return nil
 }
 
 // Transition выполняет переход из текущего состояния в другое по заданному символу и возвращает новое текущее состояние
 func (d *DFA) Transition(by *Letter) *State {
+	to, _ := d.TransitionE(by)
+	return to
+}
+
+// TransitionE выполняет переход из текущего состояния в другое по заданному символу и возвращает новое текущее состояние.
+// Если перехода по символу нет, а его имя состоит из одной руны, выполняется переход по диапазону, содержащему руну.
+// Символ должен принадлежать алфавиту; руны вне алфавита читают Accepts и Session.Step.
+// Возвращает ошибку ErrNoStartState, ErrUnknownLetter или ErrUnknownTransition, если переход невозможен
+func (d *DFA) TransitionE(by *Letter) (*State, error) {
 	if d.current == nil {
-		return nil // текущее состояние не установлено
+		return nil, ErrNoStartState // текущее состояние не установлено
 	}
 	if _, ok := d.letters[by]; !ok {
-		return nil // символ не принадлежит алфавиту ДКА
+		return nil, &LetterError{Op: "Transition", Name: letterName(by), Err: ErrUnknownLetter} // символ не принадлежит алфавиту ДКА
 	}
-	if to, ok := d.trans[d.current][by]; ok {
-		d.current = to // выполнить переход
-		return d.current
+	if to := d.next(d.current, by); to != nil {
+		if d.observer != nil {
+			d.observer.OnTransition(d.current, by, to)
+		}
+		d.current = to // выполнить переход по символу или по диапазону рун
+		return d.current, nil
 	}
-	return nil // перехода не существует
+	return nil, &TransitionError{Op: "Transition", From: d.current.name, Letter: by.name, Err: ErrUnknownTransition} // перехода не существует
 }
 
 // CheckChain проверяет цепочку символов на принадлежность языку ДКА
-// Возвращает true, если цепочка принадлежит языку ДКА, или false, если нет
+// Возвращает true, если цепочка принадлежит языку ДКА, или false, если нет.
+// Текущее состояние ДКА не изменяется, поэтому метод можно вызывать из нескольких горутин
 func (d *DFA) CheckChain(chain []*Letter) bool {
-	d.ResetCurrentState()
-	for _, l := range chain {
-		if d.Transition(l) == nil {
-			return false
-		}
-	}
-	return d.IsEndState()
+	return d.NewSession().CheckChain(chain)
 }
 
-// Accepts проверяет строку на принадлежность языку ДКА
-// Возвращает true, если строка принадлежит языку ДКА, или false, если нет
+// Accepts проверяет строку на принадлежность языку ДКА, читая её по одной руне;
+// символы с многосимвольными именами так не распознаются, для них есть AcceptsTokens
+// Возвращает true, если строка принадлежит языку ДКА, или false, если нет.
+// Текущее состояние ДКА не изменяется, поэтому метод можно вызывать из нескольких горутин
 func (d *DFA) Accepts(s string) bool {
-	d.ResetCurrentState()
-	for _, r := range s {
-		var l *Letter
-		l = d.FindLetterByName(string(r))
-		if l == nil {
-			return false
-		}
-		if d.Transition(l) == nil {
-			return false
-		}
-	}
-	return d.IsEndState()
+	return d.NewSession().Accepts(s)
 }
@@ -0,0 +1,397 @@
+package dfa
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Dict — словарь: минимальный ациклический ДКА, распознающий конечное множество слов.
+// Состояния хранятся в плоских массивах без имён и указателей, переходы каждого состояния
+// упорядочены по руне. Dict не изменяется после построения и может использоваться
+// из нескольких горутин одновременно
+type Dict struct {
+	final   []bool  // заключительность состояний
+	first   []int32 // начало переходов состояния в labels и targets; переходы s — first[s]:first[s+1]
+	labels  []rune  // руны переходов
+	targets []int32 // состояния переходов
+	weights []int64 // веса слов, заканчивающихся в состоянии, или nil для словаря без весов
+	best    []int64 // наибольший вес слова, читаемого из состояния, или nil для словаря без весов
+	start   int32   // начальное состояние
+	words   int     // число слов
+}
+
+// FromSortedWords строит словарь из слов, упорядоченных по возрастанию, инкрементальным алгоритмом
+// Дацюка и др.: после каждого слова части дерева, которые уже не могут измениться, заменяются
+// равными им ранее построенными состояниями. Память расходуется только на минимальный автомат
+// и путь последнего слова. Повторы слов пропускаются.
+// Возвращает ошибку WordError с ErrUnsorted, если слово меньше предыдущего
+func FromSortedWords(words iter.Seq[string]) (*Dict, error) {
+	return buildDict("FromSortedWords", func(yield func(string, int64) bool) {
+		for w := range words {
+			if !yield(w, 0) {
+				return
+			}
+		}
+	}, false)
+}
+
+// FromSortedWeightedWords строит словарь, как FromSortedWords, сохраняя вес каждого слова
+// в его заключительном состоянии. Состояния с разными весами не объединяются, поэтому словарь
+// минимален для множества пар из слова и веса. У повторного слова сохраняется первый вес.
+// Возвращает ошибку WordError с ErrUnsorted, если слово меньше предыдущего
+func FromSortedWeightedWords(words iter.Seq2[string, int64]) (*Dict, error) {
+	return buildDict("FromSortedWeightedWords", words, true)
+}
+
+// buildDict строит словарь из упорядоченных слов с весами; веса сохраняются, если weighted истинно
+func buildDict(op string, words iter.Seq2[string, int64], weighted bool) (*Dict, error) {
+	b := &dictBuilder{register: make(map[string]int32), path: []*dictNode{{}}, weighted: weighted}
+	var prev []rune
+	prevWord, started := "", false
+	for w, weight := range words {
+		word := []rune(w)
+		if started {
+			switch c := strings.Compare(w, prevWord); {
+			case c == 0:
+				continue
+			case c < 0:
+				return nil, &WordError{Op: op, Word: w, Err: ErrUnsorted}
+			}
+		}
+		started = true
+		k := 0
+		for k < len(prev) && k < len(word) && prev[k] == word[k] {
+			k++
+		}
+		b.freeze(k)
+		for _, r := range word[k:] {
+			tail := b.path[len(b.path)-1]
+			tail.edges = append(tail.edges, dictEdge{label: r, to: -1})
+			b.path = append(b.path, &dictNode{})
+		}
+		last := b.path[len(b.path)-1]
+		last.final, last.weight = true, weight
+		b.dict.words++
+		prev, prevWord = word, w
+	}
+	b.freeze(0)
+	b.dict.start = b.add(b.path[0])
+	b.dict.first = append(b.dict.first, int32(len(b.dict.labels)))
+	if weighted {
+		b.dict.rank()
+	}
+	return &b.dict, nil
+}
+
+// dictNode — состояние на пути последнего слова, ещё не перенесённое в словарь
+type dictNode struct {
+	final  bool
+	weight int64      // вес слова, если final
+	edges  []dictEdge // переходы; последний ведёт в следующее состояние пути, пока оно не перенесено
+}
+
+// dictEdge — переход состояния пути
+type dictEdge struct {
+	label rune
+	to    int32
+}
+
+// dictBuilder хранит словарь, построенный к текущему моменту, и путь последнего слова
+type dictBuilder struct {
+	dict     Dict
+	register map[string]int32 // состояния словаря по их записи
+	path     []*dictNode      // путь последнего слова от начального состояния
+	weighted bool             // сохранять веса слов
+}
+
+// freeze переносит в словарь состояния пути глубже depth, начиная с самого глубокого,
+// и укорачивает путь
+func (b *dictBuilder) freeze(depth int) {
+	for i := len(b.path) - 1; i > depth; i-- {
+		parent := b.path[i-1]
+		parent.edges[len(parent.edges)-1].to = b.add(b.path[i])
+	}
+	b.path = b.path[:depth+1]
+}
+
+// add возвращает состояние словаря, равное n: найденное в регистре или добавленное
+func (b *dictBuilder) add(n *dictNode) int32 {
+	key := make([]byte, 0, 1+len(n.edges)*8)
+	if n.final {
+		key = append(key, 1)
+		if b.weighted {
+			key = binary.AppendVarint(key, n.weight)
+		}
+	} else {
+		key = append(key, 0)
+	}
+	for _, e := range n.edges {
+		key = binary.AppendVarint(key, int64(e.label))
+		key = binary.AppendVarint(key, int64(e.to))
+	}
+	if id, ok := b.register[string(key)]; ok {
+		return id
+	}
+	d := &b.dict
+	id := int32(len(d.final))
+	d.final = append(d.final, n.final)
+	d.first = append(d.first, int32(len(d.labels)))
+	for _, e := range n.edges {
+		d.labels = append(d.labels, e.label)
+		d.targets = append(d.targets, e.to)
+	}
+	if b.weighted {
+		d.weights = append(d.weights, n.weight)
+	}
+	b.register[string(key)] = id
+	return id
+}
+
+// rank вычисляет для каждого состояния наибольший вес слова, читаемого из него,
+// или math.MinInt64, если из состояния не читается ни одно слово. Переходы ведут
+// в состояния с меньшими номерами, поэтому состояния обходятся по возрастанию номеров
+func (d *Dict) rank() {
+	d.best = make([]int64, len(d.final))
+	for s := range d.final {
+		best := int64(math.MinInt64)
+		if d.final[s] {
+			best = d.weights[s]
+		}
+		for _, to := range d.targets[d.first[s]:d.first[s+1]] {
+			best = max(best, d.best[to])
+		}
+		d.best[s] = best
+	}
+}
+
+// Len возвращает число слов словаря
+func (d *Dict) Len() int {
+	return d.words
+}
+
+// NumStates возвращает число состояний словаря
+func (d *Dict) NumStates() int {
+	return len(d.final)
+}
+
+// next возвращает состояние перехода из s по руне r или -1
+func (d *Dict) next(s int32, r rune) int32 {
+	lo, hi := int(d.first[s]), int(d.first[s+1])
+	i := lo + sort.Search(hi-lo, func(i int) bool { return d.labels[lo+i] >= r })
+	if i < hi && d.labels[i] == r {
+		return d.targets[i]
+	}
+	return -1
+}
+
+// walk возвращает состояние, в которое словарь переходит по строке, или -1
+func (d *Dict) walk(s string) int32 {
+	if len(d.final) == 0 {
+		return -1
+	}
+	state := d.start
+	for _, r := range s {
+		if state = d.next(state, r); state < 0 {
+			return -1
+		}
+	}
+	return state
+}
+
+// Contains проверяет, есть ли слово в словаре
+func (d *Dict) Contains(word string) bool {
+	s := d.walk(word)
+	return s >= 0 && d.final[s]
+}
+
+// Weight возвращает вес слова. Второе значение равно false, если слова нет в словаре;
+// у словаря без весов вес любого слова равен 0
+func (d *Dict) Weight(word string) (int64, bool) {
+	s := d.walk(word)
+	if s < 0 || !d.final[s] {
+		return 0, false
+	}
+	if d.weights == nil {
+		return 0, true
+	}
+	return d.weights[s], true
+}
+
+// DFA возвращает ДКА, распознающий слова словаря. Алфавит ДКА — руны слов, состояния
+// называются s0, s1, ... в порядке обхода в ширину, s0 — начальное. Веса слов в ДКА не переносятся
+func (d *Dict) DFA() *DFA {
+	if len(d.final) == 0 {
+		return NewDFA(0)
+	}
+	index := map[rune]int{}
+	for _, r := range d.labels {
+		index[r] = 0
+	}
+	t := &table{start: 0}
+	for r := range index {
+		t.syms = append(t.syms, symbol{name: string(r)})
+	}
+	sort.Slice(t.syms, func(i, j int) bool { return t.syms[i].name < t.syms[j].name })
+	for i, sym := range t.syms {
+		index[[]rune(sym.name)[0]] = i
+	}
+
+	order := make([]int32, len(d.final))
+	for i := range order {
+		order[i] = -1
+	}
+	queue := []int32{d.start}
+	order[d.start] = 0
+	for i := 0; i < len(queue); i++ {
+		s := queue[i]
+		row := emptyRow(len(t.syms))
+		for e := d.first[s]; e < d.first[s+1]; e++ {
+			to := d.targets[e]
+			if order[to] < 0 {
+				order[to] = int32(len(queue))
+				queue = append(queue, to)
+			}
+			row[index[d.labels[e]]] = int(order[to])
+		}
+		t.names = append(t.names, fmt.Sprintf("s%d", i))
+		t.term = append(t.term, d.final[s])
+		t.delta = append(t.delta, row)
+	}
+	return t.build()
+}
+
+// Заголовки записи словаря без весов и с весами
+const (
+	dictMagic         = "DFAD\x01"
+	dictMagicWeighted = "DFAD\x02"
+)
+
+// WriteTo записывает словарь в компактном двоичном виде: заголовок, числа состояний, переходов
+// и слов, начальное состояние, затем для каждого состояния число переходов и заключительность,
+// а для каждого перехода — приращение руны относительно предыдущего перехода и состояние перехода.
+// Все числа записываются в формате uvarint. Вес заключительного состояния словаря с весами
+// записывается в формате varint после числа переходов
+func (d *Dict) WriteTo(w io.Writer) (int64, error) {
+	buf := []byte(dictMagic)
+	if d.weights != nil {
+		buf = []byte(dictMagicWeighted)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(d.final)))
+	buf = binary.AppendUvarint(buf, uint64(len(d.labels)))
+	buf = binary.AppendUvarint(buf, uint64(d.words))
+	buf = binary.AppendUvarint(buf, uint64(d.start))
+	for s, final := range d.final {
+		n := uint64(d.first[s+1]-d.first[s]) << 1
+		if final {
+			n |= 1
+		}
+		buf = binary.AppendUvarint(buf, n)
+		if final && d.weights != nil {
+			buf = binary.AppendVarint(buf, d.weights[s])
+		}
+		prev := rune(0)
+		for e := d.first[s]; e < d.first[s+1]; e++ {
+			buf = binary.AppendUvarint(buf, uint64(d.labels[e]-prev))
+			buf = binary.AppendUvarint(buf, uint64(d.targets[e]))
+			prev = d.labels[e]
+		}
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// ReadDict читает словарь, записанный WriteTo.
+// Возвращает ошибку ErrBadDict, если данные не являются записью словаря
+// или переходы в ней образуют цикл
+func ReadDict(r io.Reader) (*Dict, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(dictMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, readError(err)
+	}
+	weighted := string(magic) == dictMagicWeighted
+	if string(magic) != dictMagic && !weighted {
+		return nil, ErrBadDict
+	}
+	var head [4]uint64
+	for i := range head {
+		v, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, readError(err)
+		}
+		head[i] = v
+	}
+	states, edges := head[0], head[1]
+	if states > 1<<31-1 || edges > 1<<31-1 || head[3] >= max(states, 1) {
+		return nil, ErrBadDict
+	}
+	d := &Dict{words: int(head[2]), start: int32(head[3])}
+	if states == 0 {
+		if edges != 0 || d.words != 0 {
+			return nil, ErrBadDict
+		}
+		return d, nil
+	}
+	for s := uint64(0); s < states; s++ {
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, readError(err)
+		}
+		d.final = append(d.final, n&1 == 1)
+		if weighted {
+			weight := int64(0)
+			if n&1 == 1 {
+				if weight, err = binary.ReadVarint(br); err != nil {
+					return nil, readError(err)
+				}
+			}
+			d.weights = append(d.weights, weight)
+		}
+		d.first = append(d.first, int32(len(d.labels)))
+		if n>>1 > edges-uint64(len(d.labels)) {
+			return nil, ErrBadDict
+		}
+		prev := uint64(0)
+		for e := uint64(0); e < n>>1; e++ {
+			delta, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, readError(err)
+			}
+			to, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, readError(err)
+			}
+			label := prev + delta
+			if (e > 0 && delta == 0) || label > unicode.MaxRune || to >= s {
+				return nil, ErrBadDict
+			}
+			d.labels = append(d.labels, rune(label))
+			d.targets = append(d.targets, int32(to))
+			prev = label
+		}
+	}
+	if uint64(len(d.labels)) != edges {
+		return nil, ErrBadDict
+	}
+	d.first = append(d.first, int32(len(d.labels)))
+	if weighted {
+		d.rank()
+	}
+	return d, nil
+}
+
+// readError заменяет конец данных посреди записи словаря на io.ErrUnexpectedEOF
+func readError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package dfa_test
+
+import (
+	"bytes"
+	"dfa"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestFromSortedWords(t *testing.T) {
+	words := []string{"", "кот", "кот", "котик", "коты", "рот", "ротик", "роты", "тик"}
+	d, err := dfa.FromSortedWords(slices.Values(words))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 8 {
+		t.Errorf("Len() = %d, ожидалось 8", d.Len())
+	}
+	for _, w := range words {
+		if !d.Contains(w) {
+			t.Errorf("Contains(%q) = false", w)
+		}
+	}
+	for _, w := range []string{"ко", "котики", "рот ", "ти", "кит"} {
+		if d.Contains(w) {
+			t.Errorf("Contains(%q) = true", w)
+		}
+	}
+
+	// словарь минимален: минимизация ДКА не уменьшает число состояний
+	automata := d.DFA()
+	if n := automata.Minimize().Compile().NumStates(); n != d.NumStates() {
+		t.Errorf("NumStates() = %d, у минимального ДКА %d", d.NumStates(), n)
+	}
+	var got []string
+	for w := range automata.Words(-1) {
+		got = append(got, w)
+	}
+	want := []string{"", "кот", "рот", "тик", "коты", "роты", "котик", "ротик"} // Words перечисляет по длине
+	if !slices.Equal(got, want) {
+		t.Errorf("Words = %q, ожидалось %q", got, want)
+	}
+
+	if _, err := dfa.FromSortedWords(slices.Values([]string{"б", "а"})); !errors.Is(err, dfa.ErrUnsorted) {
+		t.Errorf("неупорядоченные слова: %v", err)
+	}
+	empty, err := dfa.FromSortedWords(slices.Values([]string(nil)))
+	if err != nil || empty.Contains("") || empty.Len() != 0 {
+		t.Errorf("пустой словарь: %v", err)
+	}
+}
+
+// numbers перечисляет десятичные записи чисел от 0 до n-1 в лексикографическом порядке
+func numbers(n int) []string {
+	var words []string
+	for i := 0; i < n; i++ {
+		words = append(words, strconv.Itoa(i))
+	}
+	slices.Sort(words)
+	return words
+}
+
+func TestDictLarge(t *testing.T) {
+	words := numbers(100000)
+	d, err := dfa.FromSortedWords(slices.Values(words))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// числа от 0 до 99999: не больше пяти цифр, без ведущих нулей
+	if d.NumStates() > 8 {
+		t.Errorf("NumStates() = %d", d.NumStates())
+	}
+	for _, w := range []string{"0", "7", "99999", "12345", "100"} {
+		if !d.Contains(w) {
+			t.Errorf("Contains(%q) = false", w)
+		}
+	}
+	for _, w := range []string{"00", "012", "100000", "-1", ""} {
+		if d.Contains(w) {
+			t.Errorf("Contains(%q) = true", w)
+		}
+	}
+}
+
+func TestDictReadWrite(t *testing.T) {
+	d, _ := dfa.FromSortedWords(slices.Values(numbers(1000)))
+	var buf bytes.Buffer
+	n, err := d.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo = %d, %v", n, err)
+	}
+	data := buf.Bytes()
+	r, err := dfa.ReadDict(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 1000 || r.NumStates() != d.NumStates() || !r.Contains("999") || r.Contains("1000") {
+		t.Errorf("прочитанный словарь отличается от записанного")
+	}
+
+	if _, err := dfa.ReadDict(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("обрезанная запись: %v", err)
+	}
+	if _, err := dfa.ReadDict(bytes.NewReader([]byte("DAWG\x01"))); !errors.Is(err, dfa.ErrBadDict) {
+		t.Errorf("чужой заголовок: %v", err)
+	}
+	bad := slices.Clone(data)
+	bad[len(bad)-1] = 0x7f // переход в несуществующее состояние
+	if _, err := dfa.ReadDict(bytes.NewReader(bad)); !errors.Is(err, dfa.ErrBadDict) {
+		t.Errorf("испорченная запись: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package dfa
+
+// Equivalent проверяет, распознают ли автоматы a и b один и тот же язык над объединением
+// их алфавитов. Проверка выполняется алгоритмом Хопкрофта–Карпа на системе непересекающихся множеств.
+// Если языки различаются, вторым значением возвращается кратчайшая цепочка, которую принимает
+// ровно один из автоматов. Символы цепочки берутся из алфавита a, а отсутствующие в нём — из алфавита b;
+// руна, по которой есть только переход по диапазону, возвращается новым символом с именем из этой руны
+func Equivalent(a, b *DFA) (bool, []*Letter) {
+	syms := alphabet(a, b)
+	ta := a.tableOver(syms).complete()
+	tb := b.tableOver(syms).complete()
+
+	if hopcroftKarp(ta, tb) {
+		return true, nil
+	}
+
+	return false, ta.chain(counterexample(ta, tb), a, b)
+}
+
+// hopcroftKarp проверяет эквивалентность начальных состояний двух полных таблиц.
+// Состояния второй таблицы нумеруются со сдвигом на число состояний первой
+func hopcroftKarp(ta, tb *table) bool {
+	offset := len(ta.names)
+	parent := make([]int, offset+len(tb.names))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+
+	type pair struct{ p, q int }
+	parent[offset+tb.start] = ta.start
+	stack := []pair{{ta.start, tb.start}}
+	for len(stack) > 0 {
+		pq := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if ta.term[pq.p] != tb.term[pq.q] {
+			return false
+		}
+		for l := range ta.syms {
+			p, q := ta.delta[pq.p][l], tb.delta[pq.q][l]
+			rp, rq := find(p), find(offset+q)
+			if rp != rq {
+				parent[rq] = rp
+				stack = append(stack, pair{p, q})
+			}
+		}
+	}
+	return true
+}
+
+// counterexample ищет обходом в ширину произведения двух полных таблиц кратчайшую
+// цепочку, которую принимает ровно одна из них. Возвращает номера символов или nil
+func counterexample(ta, tb *table) []int {
+	type pair struct{ p, q int }
+	type step struct {
+		prev   pair
+		letter int
+	}
+
+	startPair := pair{ta.start, tb.start}
+	from := map[pair]step{startPair: {}}
+	queue := []pair{startPair}
+	for len(queue) > 0 {
+		pq := queue[0]
+		queue = queue[1:]
+		if ta.term[pq.p] != tb.term[pq.q] {
+			var word []int
+			for pq != startPair {
+				st := from[pq]
+				word = append(word, st.letter)
+				pq = st.prev
+			}
+			for i, j := 0, len(word)-1; i < j; i, j = i+1, j-1 {
+				word[i], word[j] = word[j], word[i]
+			}
+			return word
+		}
+		for l := range ta.syms {
+			next := pair{ta.delta[pq.p][l], tb.delta[pq.q][l]}
+			if _, ok := from[next]; !ok {
+				from[next] = step{pq, l}
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
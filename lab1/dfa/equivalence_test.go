@@ -0,0 +1,57 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+// chainString склеивает имена символов цепочки
+func chainString(chain []*dfa.Letter) string {
+	s := ""
+	for _, l := range chain {
+		s += l.String()
+	}
+	return s
+}
+
+func TestEquivalentMinimized(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	if ok, word := dfa.Equivalent(email, email.Minimize()); !ok {
+		t.Errorf("минимизация изменила язык, контрпример %q", chainString(word))
+	}
+	if ok, _ := dfa.Equivalent(email.Complement().Complement(), email); !ok {
+		t.Errorf("двойное дополнение должно сохранять язык")
+	}
+}
+
+func TestEquivalentCounterexample(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	changed := dfa.NewEmailDFA()
+	changed.RemoveTransition(changed.FindStateByName("s7"), changed.FindLetterByName("u"))
+
+	ok, word := dfa.Equivalent(email, changed)
+	if ok {
+		t.Fatalf("автоматы должны различаться")
+	}
+	if got := chainString(word); got != "a@.ru" {
+		t.Errorf("кратчайший контрпример %q, ожидался %q", got, "a@.ru")
+	}
+	if email.CheckChain(word) == changed.CheckChain(word) {
+		t.Errorf("контрпример должен различать автоматы")
+	}
+}
+
+func TestEquivalentEmptyWord(t *testing.T) {
+	a := maxLenDFA("ab", 1)
+	b := maxLenDFA("ab", 1)
+	b.RemoveState(b.FindStateByName("s0"))
+	b.AddState("s0", false)
+	b.SetTransition("s0", "s1", "a")
+	b.SetTransition("s0", "s1", "b")
+	b.SetStartState("s0")
+
+	ok, word := dfa.Equivalent(a, b)
+	if ok || word == nil || len(word) != 0 {
+		t.Errorf("ожидался пустой контрпример, получено %v %v", ok, word)
+	}
+}
@@ -0,0 +1,116 @@
+package dfa
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ошибки, которые возвращают методы ДКА с суффиксом E и алгоритмы над ДКА.
+// Проверяются через errors.Is; конкретное имя содержат StateError, LetterError и TransitionError
+var (
+	ErrUnknownState      = errors.New("состояние не принадлежит ДКА")
+	ErrUnknownLetter     = errors.New("символ не принадлежит алфавиту ДКА")
+	ErrDuplicateState    = errors.New("состояние с таким именем уже существует")
+	ErrDuplicateLetter   = errors.New("символ с таким именем уже существует")
+	ErrUnknownTransition = errors.New("перехода не существует")
+	ErrNoStartState      = errors.New("начальное состояние не установлено")
+	ErrIncomplete        = errors.New("ДКА не полон")
+	ErrNotSynchronizing  = errors.New("ДКА не имеет синхронизирующего слова")
+	ErrNoToken           = errors.New("ни один символ алфавита не совпадает с началом строки")
+	ErrNotAccepted       = errors.New("цепочка не принадлежит языку ДКА")
+	ErrEchoOutput        = errors.New("выход зависит от прочитанной руны и не выражается автоматом Мура")
+	ErrBadCounterexample = errors.New("контрпример не отличает гипотезу от языка учителя")
+	ErrUnsorted          = errors.New("слова не упорядочены по возрастанию")
+	ErrBadDict           = errors.New("неверный формат словаря")
+)
+
+// StateError описывает ошибку операции над состоянием ДКА
+type StateError struct {
+	Op   string // имя операции, например "SetTransition"
+	Name string // имя состояния
+	Err  error  // ErrUnknownState или ErrDuplicateState
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("dfa: %s: состояние %q: %v", e.Op, e.Name, e.Err)
+}
+
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
+
+// LetterError описывает ошибку операции над символом алфавита ДКА
+type LetterError struct {
+	Op   string // имя операции, например "SetTransition"
+	Name string // имя символа
+	Err  error  // ErrUnknownLetter или ErrDuplicateLetter
+}
+
+func (e *LetterError) Error() string {
+	return fmt.Sprintf("dfa: %s: символ %q: %v", e.Op, e.Name, e.Err)
+}
+
+func (e *LetterError) Unwrap() error {
+	return e.Err
+}
+
+// TransitionError описывает ошибку операции над переходом ДКА
+type TransitionError struct {
+	Op     string // имя операции, например "RemoveTransition"
+	From   string // имя исходного состояния
+	Letter string // имя символа перехода
+	Err    error  // ErrUnknownTransition
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("dfa: %s: переход из %q по %q: %v", e.Op, e.From, e.Letter, e.Err)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// TokenError описывает ошибку разбиения строки на символы алфавита ДКА
+type TokenError struct {
+	Offset int   // смещение в байтах, с которого строку не удалось разбить
+	Err    error // ErrNoToken
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("dfa: Tokenize: смещение %d: %v", e.Offset, e.Err)
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// WordError описывает ошибку обработки слова
+type WordError struct {
+	Op   string // имя операции, например "FromSortedWords"
+	Word string // слово
+	Err  error  // ErrUnsorted
+}
+
+func (e *WordError) Error() string {
+	return fmt.Sprintf("dfa: %s: слово %q: %v", e.Op, e.Word, e.Err)
+}
+
+func (e *WordError) Unwrap() error {
+	return e.Err
+}
+
+// stateName возвращает имя состояния для сообщения об ошибке
+func stateName(s *State) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return s.name
+}
+
+// letterName возвращает имя символа для сообщения об ошибке
+func letterName(l *Letter) string {
+	if l == nil {
+		return "<nil>"
+	}
+	return l.name
+}
@@ -0,0 +1,60 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("a")
+
+	if _, err := automata.AddStateE("s0", false); !errors.Is(err, dfa.ErrDuplicateState) {
+		t.Errorf("AddStateE: ожидалась ErrDuplicateState, получено %v", err)
+	}
+	if _, err := automata.AddLetterE("a"); !errors.Is(err, dfa.ErrDuplicateLetter) {
+		t.Errorf("AddLetterE: ожидалась ErrDuplicateLetter, получено %v", err)
+	}
+
+	err := automata.SetTransitionE("s0", "s9", "a")
+	var se *dfa.StateError
+	if !errors.As(err, &se) || se.Name != "s9" || !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("SetTransitionE: ожидалась StateError для s9, получено %v", err)
+	}
+	err = automata.SetTransitionE("s0", "s1", "b")
+	var le *dfa.LetterError
+	if !errors.As(err, &le) || le.Name != "b" || !errors.Is(err, dfa.ErrUnknownLetter) {
+		t.Errorf("SetTransitionE: ожидалась LetterError для b, получено %v", err)
+	}
+
+	from := automata.FindStateByName("s0")
+	by := automata.FindLetterByName("a")
+	var te *dfa.TransitionError
+	if err := automata.RemoveTransitionE(from, by); !errors.As(err, &te) || te.From != "s0" || te.Letter != "a" {
+		t.Errorf("RemoveTransitionE: ожидалась TransitionError, получено %v", err)
+	}
+
+	if _, err := automata.TransitionE(by); !errors.Is(err, dfa.ErrNoStartState) {
+		t.Errorf("TransitionE без начального состояния: получено %v", err)
+	}
+	if err := automata.SetStartStateE("s5"); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("SetStartStateE: получено %v", err)
+	}
+	if err := automata.SetEndStateE("s5"); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("SetEndStateE: получено %v", err)
+	}
+	if err := automata.RemoveStateE(nil); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("RemoveStateE(nil): получено %v", err)
+	}
+	if err := automata.RemoveLetterE(dfa.NewLetter("a")); !errors.Is(err, dfa.ErrUnknownLetter) {
+		t.Errorf("RemoveLetterE чужого символа: получено %v", err)
+	}
+
+	if err := automata.SetTransitionE("s0", "s1", "a"); err != nil {
+		t.Fatalf("SetTransitionE: %v", err)
+	}
+	if err := automata.RemoveTransitionE(from, by); err != nil {
+		t.Errorf("RemoveTransitionE: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Result — результат проверки строки с объяснением причины отказа
+type Result struct {
+	Accepted    bool     // строка принадлежит языку ДКА
+	NoStart     bool     // начальное состояние не установлено
+	Offset      int      // смещение в байтах, на котором остановилась обработка, или длина строки
+	RuneOffset  int      // номер руны, на которой остановилась обработка, или число рун строки
+	State       string   // имя состояния, в котором остановилась обработка
+	Symbol      string   // руна, по которой нет перехода; пусто, если строка прочитана целиком
+	Expected    []string // имена символов, по которым из State есть переходы
+	Ranges      Class    // руны, по которым из State есть переходы по диапазонам
+	NonTerminal bool     // строка прочитана целиком, но State не заключительное
+}
+
+// String возвращает описание результата для сообщения пользователю
+func (r Result) String() string {
+	var valid []string
+	for _, name := range r.Expected {
+		valid = append(valid, fmt.Sprintf("%q", name))
+	}
+	if len(r.Ranges) > 0 {
+		valid = append(valid, r.Ranges.String())
+	}
+	expected := "нет ни одного перехода"
+	if len(valid) > 0 {
+		expected = "допустимы " + strings.Join(valid, " ")
+	}
+	switch {
+	case r.Accepted:
+		return fmt.Sprintf("строка принята в состоянии %s", r.State)
+	case r.NoStart:
+		return "начальное состояние не установлено"
+	case r.NonTerminal:
+		return fmt.Sprintf("строка закончилась в незаключительном состоянии %s: %s", r.State, expected)
+	}
+	return fmt.Sprintf("смещение %d (руна %d): в состоянии %s нет перехода по %q: %s",
+		r.Offset, r.RuneOffset, r.State, r.Symbol, expected)
+}
+
+// Explain проверяет строку, читая её по одной руне, как Accepts, и объясняет результат:
+// где остановилась обработка, в каком состоянии, на какой руне и какие символы были бы допустимы.
+// Текущее состояние ДКА не изменяется
+func (d *DFA) Explain(s string) Result {
+	if d.start == nil {
+		return Result{NoStart: true}
+	}
+	session := &Session{dfa: d, current: d.start} // без наблюдателя: Explain только объясняет результат
+	runes := 0
+	for i, r := range s {
+		from := session.Current()
+		if session.Step(string(r)) == nil {
+			res := Result{Offset: i, RuneOffset: runes, State: from.name, Symbol: string(r)}
+			res.Expected, res.Ranges = d.expected(from)
+			return res
+		}
+		runes++
+	}
+	end := session.Current()
+	if !end.term {
+		res := Result{Offset: len(s), RuneOffset: runes, State: end.name, NonTerminal: true}
+		res.Expected, res.Ranges = d.expected(end)
+		return res
+	}
+	return Result{Accepted: true, Offset: len(s), RuneOffset: runes, State: end.name}
+}
+
+// expected возвращает имена символов, по которым из состояния есть переходы, в лексикографическом порядке,
+// и класс рун всех переходов по диапазонам
+func (d *DFA) expected(from *State) ([]string, Class) {
+	var names []string
+	for l := range d.trans[from] {
+		names = append(names, l.name)
+	}
+	sort.Strings(names)
+	var c Class
+	for _, e := range d.ranges[from] {
+		c = append(c, RuneRange{e.lo, e.hi})
+	}
+	return names, NewClass(c...)
+}
@@ -0,0 +1,59 @@
+package dfa_test
+
+import (
+	"dfa"
+	"slices"
+	"testing"
+)
+
+func TestExplainRejected(t *testing.T) {
+	r := dfa.EmailExplain("иван@mail.ru")
+	if r.Accepted || r.Offset != 0 || r.RuneOffset != 0 || r.State != "s0" || r.Symbol != "и" {
+		t.Errorf("EmailExplain(иван@mail.ru) = %+v", r)
+	}
+	if want := (dfa.Class{{Lo: 'a', Hi: 'z'}}); !slices.Equal(r.Ranges, want) || len(r.Expected) != 0 {
+		t.Errorf("допустимые символы %q %v, ожидалось %v", r.Expected, r.Ranges, want)
+	}
+
+	r = dfa.EmailExplain("user@mail.ru!")
+	if got, want := r.String(), `смещение 12 (руна 12): в состоянии s8 нет перехода по "!": нет ни одного перехода`; got != want {
+		t.Errorf("String() = %q, ожидалось %q", got, want)
+	}
+
+	// смещение в байтах и номер руны различаются после многобайтовых рун
+	r = identifierDFA().Explain("жук!")
+	if r.Offset != 6 || r.RuneOffset != 3 || r.State != "s1" || r.Symbol != "!" {
+		t.Errorf("Explain(жук!) = %+v", r)
+	}
+}
+
+func TestExplainNonTerminal(t *testing.T) {
+	r := dfa.EmailExplain("user@mail.co")
+	if r.Accepted || !r.NonTerminal || r.State != "s5" || r.Offset != 12 || r.Symbol != "" {
+		t.Fatalf("EmailExplain(user@mail.co) = %+v", r)
+	}
+	if got, want := r.String(), "строка закончилась в незаключительном состоянии s5: допустимы \"m\""; got != want {
+		t.Errorf("String() = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestExplainAgreesWithAccepts(t *testing.T) {
+	automata := maxLenDFA("ab", 2)
+	automata.AddLetter("c")
+	for _, s := range allStrings("abc", 4) {
+		r := automata.Explain(s)
+		if r.Accepted != automata.Accepts(s) {
+			t.Fatalf("Explain(%q).Accepted = %v", s, r.Accepted)
+		}
+		if !r.Accepted && !r.NonTerminal && s[r.Offset:r.Offset+1] != r.Symbol {
+			t.Errorf("Explain(%q): символ %q не на смещении %d", s, r.Symbol, r.Offset)
+		}
+	}
+	r := automata.Explain("ac")
+	if r.Symbol != "c" || !slices.Equal(r.Expected, []string{"a", "b"}) {
+		t.Errorf("Explain(ac) = %+v", r)
+	}
+	if !dfa.NewDFA(1).Explain("").NoStart {
+		t.Errorf("без начального состояния ожидалось NoStart")
+	}
+}
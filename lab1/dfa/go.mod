@@ -1,3 +1,3 @@
 module dfa
 
-go 1.21.1
+go 1.23
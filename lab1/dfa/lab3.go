@@ -1,59 +1,83 @@
 package dfa
 
-import "strconv"
-
-func enAlpAdd(aut *DFA) {
-	for i := 97; i < 123; i++ {
-		aut.AddLetter(string(rune(i)))
+import (
+	"errors"
+	"sync"
+)
+
+// domainAlpAdd добавляет в алфавит символы, которыми записаны разделители и домены адреса.
+// Остальные символы имени и домена читаются по классам и отдельных букв не требуют
+func domainAlpAdd(aut *DFA) {
+	for _, name := range []string{"@", ".", "c", "o", "m", "r", "u"} {
+		aut.AddLetter(name)
 	}
 }
 
-func numsAlpAdd(aut *DFA) {
-	for i := 0; i < 10; i++ {
-		aut.AddLetter(strconv.Itoa(i))
+// transitionsAdd задаёт переходы автомата проверки адресов.
+// Возвращает все ошибки установки переходов, чтобы опечатка в имени не прошла незамеченной
+func transitionsAdd(aut *DFA) error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	set := func(from, to, by string) {
+		check(aut.SetTransitionE(from, to, by))
 	}
-}
 
-func dotAplAdd(aut *DFA) {
-	aut.AddLetter(".")
-}
+	// символы имени и домена: латинские строчные буквы, цифры, _ и -
+	lower := NewClass(RuneRange{'a', 'z'})
+	name := lower.Union(ClassDigit).Union(NewClass(RuneRange{'_', '_'}, RuneRange{'-', '-'}))
 
-func atAplAdd(aut *DFA) {
-	aut.AddLetter("@")
-}
+	check(aut.SetClassTransition("s0", "s1", lower))
+	check(aut.SetClassTransition("s1", "s1", name))
+	check(aut.SetClassTransition("s2", "s2", name))
 
-func symAplAdd(aut *DFA) {
-	aut.AddLetter("_")
-	aut.AddLetter("-")
-}
+	set("s1", "s2", "@")
+	set("s2", "s3", ".")
 
-func transitionsAdd(aut *DFA) {
-	for i := 97; i < 123; i++ {
-		aut.SetTransition("s0", "s1", string(rune(i)))
-		aut.SetTransition("s1", "s1", string(rune(i)))
-		aut.SetTransition("s2", "s2", string(rune(i)))
-	}
+	set("s3", "s4", "c")
+	set("s4", "s5", "o")
+	set("s5", "s6", "m")
 
-	for i := 0; i < 10; i++ {
-		aut.SetTransition("s1", "s1", strconv.Itoa(i))
-		aut.SetTransition("s2", "s2", strconv.Itoa(i))
-	}
+	set("s3", "s7", "r")
+	set("s7", "s8", "u")
 
-	aut.SetTransition("s1", "s1", "_")
-	aut.SetTransition("s2", "s2", "_")
+	return errors.Join(errs...)
+}
 
-	aut.SetTransition("s1", "s1", "-")
-	aut.SetTransition("s2", "s2", "-")
+// NewEmailDFA строит ДКА, распознающий адреса электронной почты в доменах .com и .ru.
+// Символы имени и домена читаются по классам, а буквами алфавита записаны только разделители и домены
+// Паникует, если таблица переходов ссылается на несуществующие состояния или символы
+func NewEmailDFA() *DFA {
+	automata := NewDFA(9)
 
-	aut.SetTransition("s1", "s2", "@")
-	aut.SetTransition("s2", "s3", ".")
+	domainAlpAdd(automata)
 
-	aut.SetTransition("s3", "s4", "c")
-	aut.SetTransition("s4", "s5", "o")
-	aut.SetTransition("s5", "s6", "m")
+	if err := transitionsAdd(automata); err != nil {
+		panic(err)
+	}
 
-	aut.SetTransition("s3", "s7", "r")
-	aut.SetTransition("s7", "s8", "u")
+	automata.SetStartState("s0")
+	automata.SetEndState("s6")
+	automata.SetEndState("s8")
+
+	return automata
+}
+
+var (
+	emailOnce    sync.Once
+	emailDFA     *DFA
+	emailMatcher *Matcher
+)
+
+// emailInit строит и компилирует автомат проверки адресов один раз при первом обращении
+func emailInit() {
+	emailOnce.Do(func() {
+		emailDFA = NewEmailDFA()
+		emailMatcher = emailDFA.Compile()
+	})
 }
 
 func EmailCheck(s string) bool {
@@ -61,19 +85,12 @@ func EmailCheck(s string) bool {
 		return false
 	}
 
-	automata := NewDFA(9)
-
-	enAlpAdd(automata)
-	numsAlpAdd(automata)
-	dotAplAdd(automata)
-	atAplAdd(automata)
-	symAplAdd(automata)
-
-	transitionsAdd(automata)
-
-	automata.SetStartState("s0")
-	automata.SetEndState("s6")
-	automata.SetEndState("s8")
+	emailInit()
+	return emailMatcher.Accepts(s)
+}
 
-	return automata.Accepts(s)
+// EmailExplain проверяет адрес так же, как EmailCheck, и объясняет, почему он отклонён
+func EmailExplain(s string) Result {
+	emailInit()
+	return emailDFA.Explain(s)
 }
@@ -0,0 +1,180 @@
+package dfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Teacher — учитель для обучения ДКА по запросам: отвечает, принадлежит ли цепочка
+// неизвестному языку, и проверяет гипотезу. Цепочки состоят из символов алфавита,
+// переданного в Learn; сравниваются символы по именам
+type Teacher interface {
+	// Member сообщает, принадлежит ли цепочка языку
+	Member(word []*Letter) bool
+	// Equivalent сообщает, распознаёт ли гипотеза язык, а если нет — возвращает контрпример:
+	// цепочку, которую гипотеза классифицирует неверно
+	Equivalent(hypothesis *DFA) (bool, []*Letter)
+}
+
+// Learn строит минимальный полный ДКА языка учителя алгоритмом L* Англуин. Таблица наблюдений
+// хранит строки для префиксов доступа и столбцы для различающих суффиксов; контрпример
+// обрабатывается по Ривесту–Шапиру: двоичным поиском находится один суффикс, который
+// добавляется в таблицу столбцом. Ответы на запросы принадлежности запоминаются.
+// Состояния результата называются s0, s1, ... в порядке обнаружения, s0 — начальное.
+// Возвращает ошибку LetterError с ErrDuplicateLetter или ErrUnknownLetter, если алфавит содержит
+// повторы или контрпример содержит символ вне алфавита, и ErrBadCounterexample,
+// если контрпример не отличает гипотезу от ответов учителя
+func Learn(alphabet []string, teacher Teacher) (*DFA, error) {
+	l := &learner{teacher: teacher, index: make(map[string]int), member: make(map[string]bool)}
+	for _, name := range alphabet {
+		if _, ok := l.index[name]; ok {
+			return nil, &LetterError{Op: "Learn", Name: name, Err: ErrDuplicateLetter}
+		}
+		l.index[name] = len(l.letters)
+		l.letters = append(l.letters, NewLetter(name))
+	}
+	l.access = [][]int{nil}
+	l.suffixes = [][]int{nil}
+
+	for {
+		l.close()
+		hypothesis := l.hypothesis()
+		ok, counter := teacher.Equivalent(hypothesis)
+		if ok {
+			return hypothesis, nil
+		}
+		word := make([]int, len(counter))
+		for i, c := range counter {
+			a, ok := l.index[letterName(c)]
+			if !ok {
+				return nil, &LetterError{Op: "Learn", Name: letterName(c), Err: ErrUnknownLetter}
+			}
+			word[i] = a
+		}
+		if err := l.refine(word); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// learner — состояние алгоритма L*
+type learner struct {
+	teacher  Teacher
+	letters  []*Letter       // алфавит
+	index    map[string]int  // номер символа по имени
+	access   [][]int         // префиксы доступа: по одному на каждое состояние гипотезы
+	suffixes [][]int         // различающие суффиксы — столбцы таблицы наблюдений
+	rows     map[string]int  // номер префикса доступа по строке таблицы
+	delta    [][]int         // переходы гипотезы
+	member   map[string]bool // ответы на запросы принадлежности
+}
+
+// query возвращает ответ учителя о принадлежности конкатенации цепочек, запоминая его
+func (l *learner) query(parts ...[]int) bool {
+	var word []*Letter
+	var key strings.Builder
+	for _, p := range parts {
+		for _, a := range p {
+			word = append(word, l.letters[a])
+			fmt.Fprintf(&key, "%d,", a)
+		}
+	}
+	k := key.String()
+	ans, ok := l.member[k]
+	if !ok {
+		ans = l.teacher.Member(word)
+		l.member[k] = ans
+	}
+	return ans
+}
+
+// row возвращает строку таблицы наблюдений для префикса
+func (l *learner) row(prefix []int) string {
+	b := make([]byte, len(l.suffixes))
+	for i, e := range l.suffixes {
+		b[i] = '0'
+		if l.query(prefix, e) {
+			b[i] = '1'
+		}
+	}
+	return string(b)
+}
+
+// extend возвращает префикс, продолженный символом a
+func extend(prefix []int, a int) []int {
+	return append(prefix[:len(prefix):len(prefix)], a)
+}
+
+// close пересчитывает таблицу после добавления столбца и дополняет её до замкнутой:
+// каждое продолжение префикса доступа символом получает строку, равную строке какого-то префикса доступа.
+// Префиксы доступа попарно различимы, поэтому таблица всегда согласована
+func (l *learner) close() {
+	l.rows = make(map[string]int, len(l.access))
+	for i, p := range l.access {
+		l.rows[l.row(p)] = i
+	}
+	l.delta = l.delta[:0]
+	for s := 0; s < len(l.access); s++ {
+		next := make([]int, len(l.letters))
+		for a := range l.letters {
+			ext := extend(l.access[s], a)
+			r := l.row(ext)
+			to, ok := l.rows[r]
+			if !ok {
+				to = len(l.access)
+				l.access = append(l.access, ext)
+				l.rows[r] = to
+			}
+			next[a] = to
+		}
+		l.delta = append(l.delta, next)
+	}
+}
+
+// hypothesis строит ДКА по замкнутой таблице наблюдений
+func (l *learner) hypothesis() *DFA {
+	t := &table{start: 0}
+	for _, a := range l.letters {
+		t.syms = append(t.syms, symbol{name: a.name})
+	}
+	for s, p := range l.access {
+		t.names = append(t.names, fmt.Sprintf("s%d", s))
+		t.term = append(t.term, l.query(p))
+	}
+	t.delta = l.delta
+	d, _, _ := t.buildIndexed()
+	return d
+}
+
+// state возвращает номер состояния гипотезы после чтения цепочки
+func (l *learner) state(word []int) int {
+	s := 0
+	for _, a := range word {
+		s = l.delta[s][a]
+	}
+	return s
+}
+
+// refine обрабатывает контрпример по Ривесту–Шапиру. Пусть alpha(i) — ответ учителя на цепочку
+// из префикса доступа состояния, в которое гипотеза приходит по первым i символам, и остатка контрпримера.
+// alpha(0) — ответ на сам контрпример, alpha(len) — ответ гипотезы; раз они различаются, двоичный поиск
+// находит i, для которого alpha(i) != alpha(i+1), и остаток после i+1 символов становится новым столбцом
+func (l *learner) refine(word []int) error {
+	alpha := func(i int) bool {
+		return l.query(l.access[l.state(word[:i])], word[i:])
+	}
+	lo, hi := 0, len(word)
+	if alpha(lo) == alpha(hi) {
+		return ErrBadCounterexample
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if alpha(mid) == alpha(lo) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	l.suffixes = append(l.suffixes, word[hi:])
+	return nil
+}
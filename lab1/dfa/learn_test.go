@@ -0,0 +1,134 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// dfaTeacher отвечает на запросы по известному ДКА, сопоставляя символы по именам,
+// и считает запросы принадлежности
+type dfaTeacher struct {
+	target  *dfa.DFA
+	members map[string]int
+}
+
+func (t *dfaTeacher) Member(word []*dfa.Letter) bool {
+	if t.members == nil {
+		t.members = map[string]int{}
+	}
+	t.members[chainString(word)]++
+	chain := make([]*dfa.Letter, len(word))
+	for i, l := range word {
+		if chain[i] = t.target.FindLetterByName(l.String()); chain[i] == nil {
+			return false
+		}
+	}
+	return t.target.CheckChain(chain)
+}
+
+func (t *dfaTeacher) Equivalent(hypothesis *dfa.DFA) (bool, []*dfa.Letter) {
+	return dfa.Equivalent(t.target, hypothesis)
+}
+
+// protocolDFA строит автомат протокола: login, затем любое число get, затем logout
+func protocolDFA() *dfa.DFA {
+	automata := dfa.NewDFA(3)
+	for _, l := range []string{"login", "get", "logout"} {
+		automata.AddLetter(l)
+	}
+	automata.SetTransition("s0", "s1", "login")
+	automata.SetTransition("s1", "s1", "get")
+	automata.SetTransition("s1", "s2", "logout")
+	automata.SetStartState("s0")
+	automata.SetEndState("s2")
+	return automata
+}
+
+// modCountDFA строит автомат цепочек над {a, b}, в которых число символов a делится на n
+// и которые оканчиваются на b
+func modCountDFA(n int) *dfa.DFA {
+	automata := dfa.NewDFA(0)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	name := func(i int, b bool) string {
+		if b {
+			return "b" + string(rune('0'+i))
+		}
+		return "q" + string(rune('0'+i))
+	}
+	for i := 0; i < n; i++ {
+		automata.AddState(name(i, false), false)
+		automata.AddState(name(i, true), i == 0)
+	}
+	for i := 0; i < n; i++ {
+		for _, b := range []bool{false, true} {
+			automata.SetTransition(name(i, b), name((i+1)%n, false), "a")
+			automata.SetTransition(name(i, b), name(i, true), "b")
+		}
+	}
+	automata.SetStartState("q0")
+	return automata
+}
+
+func TestLearn(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		target   *dfa.DFA
+		alphabet []string
+		states   int
+	}{
+		{"протокол", protocolDFA(), []string{"login", "get", "logout"}, 4},
+		{"a mod 3", modCountDFA(3), []string{"a", "b"}, 4},
+		{"a mod 5", modCountDFA(5), []string{"a", "b"}, 6},
+	} {
+		teacher := &dfaTeacher{target: c.target}
+		learned, err := dfa.Learn(c.alphabet, teacher)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if ok, word := dfa.Equivalent(c.target, learned); !ok {
+			t.Errorf("%s: выученный ДКА отличается на %q", c.name, chainString(word))
+		}
+		if n := learned.Compile().NumStates(); n != c.states {
+			t.Errorf("%s: %d состояний, ожидалось %d", c.name, n, c.states)
+		}
+		if learned.GetStartState().String() != "s0" {
+			t.Errorf("%s: начальное состояние %v", c.name, learned.GetStartState())
+		}
+		for word, n := range teacher.members {
+			if n > 1 {
+				t.Errorf("%s: запрос %q задан %d раз", c.name, word, n)
+			}
+		}
+	}
+}
+
+// liar отвечает на запросы принадлежности по ДКА, а контрпримером всегда называет пустую цепочку
+type liar struct{ dfaTeacher }
+
+func (l *liar) Equivalent(hypothesis *dfa.DFA) (bool, []*dfa.Letter) {
+	return false, nil
+}
+
+// stranger возвращает контрпример из символа вне алфавита
+type stranger struct{ dfaTeacher }
+
+func (s *stranger) Equivalent(hypothesis *dfa.DFA) (bool, []*dfa.Letter) {
+	return false, []*dfa.Letter{dfa.NewLetter("c")}
+}
+
+func TestLearnErrors(t *testing.T) {
+	target := modCountDFA(2)
+	if _, err := dfa.Learn([]string{"a", "b", "a"}, &dfaTeacher{target: target}); !errors.Is(err, dfa.ErrDuplicateLetter) {
+		t.Errorf("повтор в алфавите: %v", err)
+	}
+	if _, err := dfa.Learn([]string{"a", "b"}, &liar{dfaTeacher{target: target}}); !errors.Is(err, dfa.ErrBadCounterexample) {
+		t.Errorf("неверный контрпример: %v", err)
+	}
+	_, err := dfa.Learn([]string{"a", "b"}, &stranger{dfaTeacher{target: target}})
+	if !errors.Is(err, dfa.ErrUnknownLetter) || !strings.Contains(err.Error(), `"c"`) {
+		t.Errorf("символ вне алфавита: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package dfa
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Matcher — неизменяемое скомпилированное представление ДКА.
+// Состояния и символы перенумерованы плотными целыми числами, а функция переходов
+// хранится в одной плоской таблице, поэтому проверка цепочки не обращается к отображениям ДКА.
+// Matcher не изменяется после создания и может использоваться из нескольких горутин одновременно
+type Matcher struct {
+	delta    []int32              // delta[s*nletters+l] — номер следующего состояния или -1
+	nletters int                  // число символов алфавита
+	start    int32                // номер начального состояния или -1
+	accept   []bool               // флаги заключительности состояний
+	ascii    [utf8.RuneSelf]int32 // номер символа для ASCII-рун или -1
+	runes    map[rune]int32       // номер символа для остальных рун-букв
+	spans    []RuneRange          // упорядоченные диапазоны рун, по которым нет букв
+	spanSyms []int32              // spanSyms[i] — номер символа диапазона spans[i]
+}
+
+// Compile строит скомпилированное представление ДКА.
+// Последующие изменения ДКА не отражаются на построенном Matcher
+func (d *DFA) Compile() *Matcher {
+	t := d.table()
+	m := &Matcher{
+		delta:    make([]int32, len(t.names)*len(t.syms)),
+		nletters: len(t.syms),
+		start:    int32(t.start),
+		accept:   t.term,
+		runes:    make(map[rune]int32),
+	}
+	for i := range m.ascii {
+		m.ascii[i] = -1
+	}
+	for l, sym := range t.syms {
+		if sym.isSpan {
+			m.spans = append(m.spans, sym.span)
+			m.spanSyms = append(m.spanSyms, int32(l))
+			continue
+		}
+		r, ok := singleRune(sym.name)
+		if !ok {
+			continue // символ не является одной руной и не встречается во входной строке
+		}
+		if r < utf8.RuneSelf {
+			m.ascii[r] = int32(l)
+		} else {
+			m.runes[r] = int32(l)
+		}
+	}
+	for r := range m.ascii {
+		if m.ascii[r] < 0 {
+			m.ascii[r] = m.span(rune(r))
+		}
+	}
+	for s, row := range t.delta {
+		for l, to := range row {
+			m.delta[s*m.nletters+l] = int32(to)
+		}
+	}
+	return m
+}
+
+// NumStates возвращает число состояний скомпилированного автомата
+func (m *Matcher) NumStates() int {
+	return len(m.accept)
+}
+
+// Start возвращает номер начального состояния или -1, если оно не установлено
+func (m *Matcher) Start() int {
+	return int(m.start)
+}
+
+// IsAccepting возвращает true, если состояние с заданным номером заключительное
+func (m *Matcher) IsAccepting(state int) bool {
+	return state >= 0 && m.accept[state]
+}
+
+// letter возвращает номер символа алфавита, соответствующего руне, или -1
+func (m *Matcher) letter(r rune) int32 {
+	if r >= 0 && r < utf8.RuneSelf {
+		return m.ascii[r]
+	}
+	if l, ok := m.runes[r]; ok {
+		return l
+	}
+	return m.span(r)
+}
+
+// span возвращает номер символа диапазона, содержащего руну, или -1
+func (m *Matcher) span(r rune) int32 {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].Hi >= r })
+	if i < len(m.spans) && m.spans[i].Lo <= r {
+		return m.spanSyms[i]
+	}
+	return -1
+}
+
+// Step выполняет переход из заданного состояния по руне и возвращает номер нового состояния
+// или -1, если перехода нет
+func (m *Matcher) Step(state int, r rune) int {
+	if state < 0 {
+		return -1
+	}
+	l := m.letter(r)
+	if l < 0 {
+		return -1
+	}
+	return int(m.delta[state*m.nletters+int(l)])
+}
+
+// Accepts проверяет строку на принадлежность языку автомата
+// Возвращает true, если строка принадлежит языку, или false, если нет
+func (m *Matcher) Accepts(s string) bool {
+	state := m.start
+	if state < 0 {
+		return false
+	}
+	for i := 0; i < len(s); {
+		var l int32
+		if c := s[i]; c < utf8.RuneSelf {
+			l = m.ascii[c]
+			i++
+		} else {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			l = m.letter(r)
+			i += size
+		}
+		if l < 0 {
+			return false
+		}
+		state = m.delta[int(state)*m.nletters+int(l)]
+		if state < 0 {
+			return false
+		}
+	}
+	return m.accept[state]
+}
@@ -0,0 +1,72 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+var emailSamples = []string{
+	"vladimirov_d1ma@mail.ru", "a@b.com", "a@.ru", "xd", "", "a@b.co", "1a@b.ru",
+	"a@b.com.", "ab-c@d_e.ru", "почта@mail.ru", "a@b.ruu", "a\xffb@c.ru",
+}
+
+func TestMatcherAgreesWithDFA(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	m := email.Compile()
+	for _, s := range emailSamples {
+		if got, want := m.Accepts(s), email.Accepts(s); got != want {
+			t.Errorf("Matcher.Accepts(%q) = %v, DFA.Accepts = %v", s, got, want)
+		}
+	}
+	if m.NumStates() != 9 {
+		t.Errorf("NumStates() = %d", m.NumStates())
+	}
+}
+
+func TestMatcherStep(t *testing.T) {
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("ж")
+	automata.AddLetter("ab") // не руна: во входной строке не встречается
+	automata.SetTransition("s0", "s1", "ж")
+	automata.SetTransition("s1", "s0", "ab")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+
+	m := automata.Compile()
+	s := m.Step(m.Start(), 'ж')
+	if !m.IsAccepting(s) {
+		t.Fatalf("переход по руне 'ж' должен вести в заключительное состояние")
+	}
+	if m.Step(s, 'ж') != -1 || m.Step(s, 'a') != -1 {
+		t.Errorf("несуществующий переход должен возвращать -1")
+	}
+	if m.Accepts("жab") {
+		t.Errorf("многосимвольные буквы не должны сопоставляться с рунами")
+	}
+
+	// изменения ДКА после компиляции не влияют на Matcher
+	automata.SetTransition("s1", "s1", "ж")
+	if m.Accepts("жж") {
+		t.Errorf("Matcher должен быть неизменяемым")
+	}
+}
+
+func TestMatcherNoStart(t *testing.T) {
+	if dfa.NewDFA(1).Compile().Accepts("") {
+		t.Errorf("автомат без начального состояния не принимает цепочек")
+	}
+}
+
+func BenchmarkEmailDFA(b *testing.B) {
+	email := dfa.NewEmailDFA()
+	for i := 0; i < b.N; i++ {
+		email.Accepts("vladimirov_d1ma@mail.ru")
+	}
+}
+
+func BenchmarkEmailMatcher(b *testing.B) {
+	m := dfa.NewEmailDFA().Compile()
+	for i := 0; i < b.N; i++ {
+		m.Accepts("vladimirov_d1ma@mail.ru")
+	}
+}
@@ -0,0 +1,200 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Minimize возвращает новый ДКА с минимальным числом состояний, распознающий тот же язык.
+// Перед минимизацией удаляются недостижимые и тупиковые состояния, затем классы
+// эквивалентных состояний находятся алгоритмом Хопкрофта. Объединённое состояние
+// получает имя вида {s1,s2}, составленное из имён исходных состояний
+func (d *DFA) Minimize() *DFA {
+	return d.table().trim().minimize().build()
+}
+
+// minimize строит минимальный автомат по плотному представлению без тупиковых состояний
+func (t *table) minimize() *table {
+	r, _ := t.minimizeBy(func(s int) string { return strconv.FormatBool(t.term[s]) }, nil)
+	return r
+}
+
+// minimizeBy объединяет эквивалентные состояния плотного представления без тупиковых состояний
+// алгоритмом Хопкрофта. Эквивалентные состояния имеют одинаковый ключ key, переходы по каждому символу
+// ведут в эквивалентные состояния и, если задан edge, имеют одинаковый ключ edge; ключ key должен
+// различать заключительные и незаключительные состояния. Объединённое состояние получает имя вида {s1,s2},
+// составленное из имён исходных состояний. Возвращает новую таблицу и номер исходного
+// состояния-представителя каждого её состояния
+func (t *table) minimizeBy(key func(s int) string, edge func(s, l int) string) (*table, []int) {
+	n := len(t.names)
+	if n == 0 {
+		return t, nil
+	}
+	if t.start >= 0 && !t.live()[t.start] {
+		// язык пуст: остаётся одно незаключительное начальное состояние
+		return &table{
+			names: []string{t.names[t.start]},
+			term:  []bool{false},
+			syms:  t.syms,
+			delta: [][]int{emptyRow(len(t.syms))},
+			start: 0,
+		}, []int{t.start}
+	}
+
+	// недостающие переходы ведут в фиктивное состояние-сток с номером n
+	sink := n
+	inv := make([][][]int, len(t.syms)) // inv[l][q] — состояния, переходящие в q по символу l
+	for l := range t.syms {
+		inv[l] = make([][]int, n+1)
+		for s := 0; s <= n; s++ {
+			to := sink
+			if s < n && t.delta[s][l] >= 0 {
+				to = t.delta[s][l]
+			}
+			inv[l][to] = append(inv[l][to], s)
+		}
+	}
+
+	// начальное разбиение: состояния с одинаковыми ключами и ключами переходов, сток отдельно
+	var blocks [][]int
+	blockOf := make([]int, n+1)
+	ids := make(map[string]int)
+	for s := 0; s < n; s++ {
+		var b strings.Builder
+		b.WriteString(strconv.Quote(key(s)))
+		for l, to := range t.delta[s] {
+			if edge != nil && to >= 0 {
+				fmt.Fprintf(&b, "|%d:%s", l, edge(s, l))
+			}
+		}
+		id, ok := ids[b.String()]
+		if !ok {
+			id = len(blocks)
+			ids[b.String()] = id
+			blocks = append(blocks, nil)
+		}
+		blockOf[s] = id
+		blocks[id] = append(blocks[id], s)
+	}
+	blockOf[sink] = len(blocks)
+	blocks = append(blocks, []int{sink})
+
+	var work []int
+	inWork := make([]bool, len(blocks))
+	for b := range blocks {
+		work = append(work, b)
+		inWork[b] = true
+	}
+
+	mark := make([]bool, n+1)
+	for len(work) > 0 {
+		a := work[len(work)-1]
+		work = work[:len(work)-1]
+		inWork[a] = false
+		splitter := append([]int(nil), blocks[a]...)
+
+		for l := range t.syms {
+			// состояния, переходящие в блок-разделитель по символу l
+			var pre []int
+			for _, q := range splitter {
+				for _, p := range inv[l][q] {
+					if !mark[p] {
+						mark[p] = true
+						pre = append(pre, p)
+					}
+				}
+			}
+
+			touched := make(map[int]bool)
+			for _, p := range pre {
+				touched[blockOf[p]] = true
+			}
+			ids := make([]int, 0, len(touched))
+			for b := range touched {
+				ids = append(ids, b)
+			}
+			sort.Ints(ids)
+
+			for _, b := range ids {
+				var in, out []int
+				for _, s := range blocks[b] {
+					if mark[s] {
+						in = append(in, s)
+					} else {
+						out = append(out, s)
+					}
+				}
+				if len(out) == 0 {
+					continue // блок не расщепляется
+				}
+				nb := len(blocks)
+				blocks[b] = out
+				blocks = append(blocks, in)
+				inWork = append(inWork, false)
+				for _, s := range in {
+					blockOf[s] = nb
+				}
+				if inWork[b] || len(in) <= len(out) {
+					work = append(work, nb)
+					inWork[nb] = true
+				} else {
+					work = append(work, b)
+					inWork[b] = true
+				}
+			}
+
+			for _, p := range pre {
+				mark[p] = false
+			}
+		}
+	}
+
+	// перенумеровать блоки в порядке наименьших исходных состояний, исключив блок стока
+	index := make([]int, len(blocks))
+	for b := range index {
+		index[b] = -1
+	}
+	r := &table{syms: t.syms, start: -1}
+	var members [][]int
+	for s := 0; s < n; s++ {
+		b := blockOf[s]
+		if b == blockOf[sink] {
+			continue
+		}
+		if index[b] < 0 {
+			index[b] = len(members)
+			members = append(members, nil)
+		}
+		members[index[b]] = append(members[index[b]], s)
+	}
+	rep := make([]int, len(members))
+	for i, ms := range members {
+		rep[i] = ms[0]
+		names := make([]string, len(ms))
+		for j, s := range ms {
+			names[j] = t.names[s]
+		}
+		name := names[0]
+		if len(names) > 1 {
+			sort.Strings(names)
+			name = "{" + strings.Join(names, ",") + "}"
+		}
+		r.names = append(r.names, name)
+		r.term = append(r.term, t.term[ms[0]])
+
+		row := make([]int, len(t.syms))
+		for l := range row {
+			row[l] = -1
+			if to := t.delta[ms[0]][l]; to >= 0 {
+				row[l] = index[blockOf[to]]
+			}
+		}
+		r.delta = append(r.delta, row)
+	}
+	if t.start >= 0 {
+		r.start = index[blockOf[t.start]]
+	}
+	return r, rep
+}
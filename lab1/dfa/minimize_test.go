@@ -0,0 +1,75 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+func TestMinimizeEmail(t *testing.T) {
+	automata := dfa.NewEmailDFA()
+	min := automata.Minimize()
+
+	if min.FindStateByName("{s6,s8}") == nil {
+		t.Errorf("заключительные состояния s6 и s8 должны объединиться")
+	}
+	if min.FindStateByName("s6") != nil || min.FindStateByName("s8") != nil {
+		t.Errorf("объединённые состояния не должны остаться в автомате")
+	}
+
+	for _, s := range []string{
+		"vladimirov_d1ma@mail.ru", "a@b.com", "a@.ru", "xd", "", "a@b.co", "1a@b.ru", "a@b.com.",
+	} {
+		if automata.Accepts(s) != min.Accepts(s) {
+			t.Errorf("Accepts(%q): исходный %v, минимальный %v", s, automata.Accepts(s), min.Accepts(s))
+		}
+	}
+}
+
+func TestMinimizeRedundant(t *testing.T) {
+	// s0..s3 различают чётность длины дважды, а s4 недостижимо
+	automata := dfa.NewDFA(5)
+	automata.AddLetter("a")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s1", "s2", "a")
+	automata.SetTransition("s2", "s3", "a")
+	automata.SetTransition("s3", "s0", "a")
+	automata.SetTransition("s4", "s0", "a")
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+	automata.SetEndState("s2")
+
+	min := automata.Minimize()
+	if min.FindStateByName("{s0,s2}") == nil || min.FindStateByName("{s1,s3}") == nil {
+		t.Fatalf("ожидались состояния {s0,s2} и {s1,s3}")
+	}
+	if min.FindStateByName("s4") != nil {
+		t.Errorf("недостижимое состояние s4 должно быть удалено")
+	}
+	if min.GetStartState().String() != "{s0,s2}" {
+		t.Errorf("начальное состояние: %v", min.GetStartState())
+	}
+	for i, want := range []bool{true, false, true, false, true} {
+		s := ""
+		for j := 0; j < i; j++ {
+			s += "a"
+		}
+		if min.Accepts(s) != want {
+			t.Errorf("Accepts(%q) = %v, ожидалось %v", s, !want, want)
+		}
+	}
+}
+
+func TestMinimizeEmpty(t *testing.T) {
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("a")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetStartState("s0")
+
+	min := automata.Minimize()
+	if min.GetStartState() == nil || min.GetStartState().IsTerminal() {
+		t.Fatalf("пустой язык должен давать одно незаключительное начальное состояние")
+	}
+	if min.FindStateByName("s1") != nil {
+		t.Errorf("тупиковое состояние s1 должно быть удалено")
+	}
+}
@@ -0,0 +1,120 @@
+package dfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Observer получает уведомления о ходе работы ДКА. OnTransition вызывается после каждого
+// выполненного перехода, OnAccept и OnReject — по окончании проверки цепочки или строки
+// (CheckChain и Accepts ДКА и сессии). Если проверки выполняются из нескольких горутин,
+// наблюдатель должен быть безопасен для одновременного использования
+type Observer interface {
+	// OnTransition сообщает о переходе из from в to по символу by. Переход по диапазону рун
+	// сообщается с символом, имя которого состоит из прочитанной руны
+	OnTransition(from *State, by *Letter, to *State)
+	// OnReject сообщает, что цепочка отвергнута в состоянии at: по символу by нет перехода,
+	// а если by равен nil — цепочка прочитана целиком, но at не заключительное.
+	// Если начальное состояние не установлено, at равно nil
+	OnReject(at *State, by *Letter)
+	// OnAccept сообщает, что цепочка принята в заключительном состоянии at
+	OnAccept(at *State)
+}
+
+// SetObserver подключает наблюдатель к ДКА: он получает уведомления о переходах Transition
+// и о проверках CheckChain и Accepts. Сессии, созданные после вызова, наследуют наблюдатель.
+// Значение nil отключает наблюдение
+func (d *DFA) SetObserver(o Observer) {
+	d.observer = o
+}
+
+// TraceStep — один переход записанного выполнения
+type TraceStep struct {
+	From   *State  // исходное состояние
+	Letter *Letter // символ перехода
+	To     *State  // состояние перехода
+}
+
+// TraceRecorder — наблюдатель, записывающий выполнение целиком: переходы, последнее состояние
+// и итог проверки. Запись новой проверки после завершения предыдущей начинается заново.
+// TraceRecorder не предназначен для одновременного использования несколькими горутинами
+type TraceRecorder struct {
+	Steps    []TraceStep // выполненные переходы по порядку
+	Final    *State      // состояние, в котором закончилась проверка
+	Blocked  *Letter     // символ, по которому не оказалось перехода, или nil
+	Done     bool        // проверка завершена
+	Accepted bool        // цепочка принята
+}
+
+// OnTransition записывает переход
+func (r *TraceRecorder) OnTransition(from *State, by *Letter, to *State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Steps = append(r.Steps, TraceStep{From: from, Letter: by, To: to})
+}
+
+// OnReject записывает отказ
+func (r *TraceRecorder) OnReject(at *State, by *Letter) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Blocked, r.Done = at, by, true
+}
+
+// OnAccept записывает принятие цепочки
+func (r *TraceRecorder) OnAccept(at *State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Done, r.Accepted = at, true, true
+}
+
+// Reset очищает запись
+func (r *TraceRecorder) Reset() {
+	*r = TraceRecorder{}
+}
+
+// Path возвращает путь по состояниям: исходное состояние первого перехода и состояния
+// после каждого перехода. Если переходов не было, путь состоит из последнего состояния
+func (r *TraceRecorder) Path() []*State {
+	if len(r.Steps) == 0 {
+		if r.Final == nil {
+			return nil
+		}
+		return []*State{r.Final}
+	}
+	path := []*State{r.Steps[0].From}
+	for _, st := range r.Steps {
+		path = append(path, st.To)
+	}
+	return path
+}
+
+// String возвращает запись выполнения вида s0 -a-> s1 -b-> s2: принята
+func (r *TraceRecorder) String() string {
+	var b strings.Builder
+	for i, s := range r.Path() {
+		if i > 0 {
+			fmt.Fprintf(&b, " -%s-> ", r.Steps[i-1].Letter)
+		}
+		b.WriteString(stateName(s))
+	}
+	verdict := ""
+	switch {
+	case !r.Done:
+	case r.Accepted:
+		verdict = "принята"
+	case r.Final == nil:
+		verdict = "начальное состояние не установлено"
+	case r.Blocked != nil:
+		verdict = fmt.Sprintf("нет перехода по %q", r.Blocked.name)
+	default:
+		verdict = "состояние не заключительное"
+	}
+	if verdict != "" && b.Len() > 0 {
+		b.WriteString(": ")
+	}
+	b.WriteString(verdict)
+	return b.String()
+}
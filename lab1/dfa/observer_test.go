@@ -0,0 +1,77 @@
+package dfa_test
+
+import (
+	"dfa"
+	"strings"
+	"testing"
+)
+
+// countingObserver считает уведомления каждого вида
+type countingObserver struct {
+	transitions, rejects, accepts int
+}
+
+func (c *countingObserver) OnTransition(from *dfa.State, by *dfa.Letter, to *dfa.State) {
+	c.transitions++
+}
+func (c *countingObserver) OnReject(at *dfa.State, by *dfa.Letter) { c.rejects++ }
+func (c *countingObserver) OnAccept(at *dfa.State)                 { c.accepts++ }
+
+func TestTraceRecorder(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	trace := &dfa.TraceRecorder{}
+	email.SetObserver(trace)
+
+	if !email.Accepts("ab@c.ru") {
+		t.Fatalf("адрес должен быть принят")
+	}
+	if got, want := trace.String(), "s0 -a-> s1 -b-> s1 -@-> s2 -c-> s2 -.-> s3 -r-> s7 -u-> s8: принята"; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+	if path := trace.Path(); len(path) != 8 || path[7].String() != "s8" {
+		t.Errorf("Path() = %v", path)
+	}
+
+	// следующая проверка записывается заново
+	email.Accepts("ab@c.r!")
+	if got, want := trace.String(), `s0 -a-> s1 -b-> s1 -@-> s2 -c-> s2 -.-> s3 -r-> s7: нет перехода по "!"`; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+	if trace.Accepted || trace.Final.String() != "s7" || trace.Blocked.String() != "!" {
+		t.Errorf("отказ записан неверно: %+v", trace)
+	}
+
+	email.Accepts("ab@c.co")
+	if trace.Blocked != nil || trace.Final.String() != "s5" || !strings.HasSuffix(trace.String(), ": состояние не заключительное") {
+		t.Errorf("незаключительное состояние записано неверно: %s", trace)
+	}
+}
+
+func TestObserverAttachment(t *testing.T) {
+	automata := maxLenDFA("ab", 2)
+	counter := &countingObserver{}
+	automata.SetObserver(counter)
+
+	automata.ResetCurrentState()
+	automata.Transition(automata.FindLetterByName("a"))
+	automata.CheckChain([]*dfa.Letter{automata.FindLetterByName("a"), automata.FindLetterByName("b")})
+	automata.Accepts("abb")
+	if counter.transitions != 1+2+2 || counter.accepts != 1 || counter.rejects != 1 {
+		t.Errorf("уведомления: %+v", *counter)
+	}
+
+	// наблюдатель сессии заменяет унаследованный
+	session := automata.NewSession()
+	trace := &dfa.TraceRecorder{}
+	session.SetObserver(trace)
+	session.Accepts("b")
+	if counter.transitions != 5 || len(trace.Steps) != 1 || !trace.Accepted {
+		t.Errorf("наблюдатель сессии: %+v, %+v", *counter, *trace)
+	}
+
+	automata.SetObserver(nil)
+	automata.Accepts("ab")
+	if counter.accepts != 1 {
+		t.Errorf("отключённый наблюдатель получил уведомление")
+	}
+}
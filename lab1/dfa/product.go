@@ -0,0 +1,75 @@
+package dfa
+
+import "fmt"
+
+// Intersect возвращает ДКА, распознающий пересечение языков автоматов a и b
+func Intersect(a, b *DFA) *DFA {
+	return product(a, b, func(x, y bool) bool { return x && y })
+}
+
+// Union возвращает ДКА, распознающий объединение языков автоматов a и b
+func Union(a, b *DFA) *DFA {
+	return product(a, b, func(x, y bool) bool { return x || y })
+}
+
+// Difference возвращает ДКА, распознающий цепочки языка a, не принадлежащие языку b
+func Difference(a, b *DFA) *DFA {
+	return product(a, b, func(x, y bool) bool { return x && !y })
+}
+
+// SymmetricDifference возвращает ДКА, распознающий цепочки, принадлежащие ровно одному из языков a и b
+func SymmetricDifference(a, b *DFA) *DFA {
+	return product(a, b, func(x, y bool) bool { return x != y })
+}
+
+// productTable строит произведение двух полных таблиц над общим алфавитом.
+// В результат попадают только пары, достижимые из пары начальных состояний;
+// пара заключительна, если accept возвращает true для флагов её компонент
+func productTable(ta, tb *table, accept func(x, y bool) bool) *table {
+	type pair struct{ p, q int }
+
+	r := &table{syms: ta.syms, start: 0}
+	index := map[pair]int{}
+	taken := map[string]bool{}
+	var queue []pair
+	visit := func(pq pair) int {
+		if i, ok := index[pq]; ok {
+			return i
+		}
+		i := len(r.names)
+		index[pq] = i
+		// имена компонент могут содержать запятые, например после Minimize,
+		// поэтому разные пары могут получить одно имя: оно дополняется номером
+		name := "(" + ta.names[pq.p] + "," + tb.names[pq.q] + ")"
+		for k := 1; taken[name]; k++ {
+			name = fmt.Sprintf("(%s,%s)%d", ta.names[pq.p], tb.names[pq.q], k)
+		}
+		taken[name] = true
+		r.names = append(r.names, name)
+		r.term = append(r.term, accept(ta.term[pq.p], tb.term[pq.q]))
+		r.delta = append(r.delta, nil)
+		queue = append(queue, pq)
+		return i
+	}
+
+	visit(pair{ta.start, tb.start})
+	for len(queue) > 0 {
+		pq := queue[0]
+		queue = queue[1:]
+		row := make([]int, len(r.syms))
+		for l := range row {
+			row[l] = visit(pair{ta.delta[pq.p][l], tb.delta[pq.q][l]})
+		}
+		r.delta[index[pq]] = row
+	}
+	return r
+}
+
+// product строит произведение автоматов a и b над объединением их алфавитов.
+// Перед построением оба автомата дополняются состоянием-стоком
+func product(a, b *DFA, accept func(x, y bool) bool) *DFA {
+	syms := alphabet(a, b)
+	ta := a.tableOver(syms).complete()
+	tb := b.tableOver(syms).complete()
+	return productTable(ta, tb, accept).build()
+}
@@ -0,0 +1,106 @@
+package dfa_test
+
+import (
+	"dfa"
+	"fmt"
+	"testing"
+)
+
+// maxLenDFA строит ДКА, распознающий цепочки над алфавитом letters длиной не более n
+func maxLenDFA(letters string, n int) *dfa.DFA {
+	automata := dfa.NewDFA(n + 1)
+	for _, r := range letters {
+		automata.AddLetter(string(r))
+	}
+	for i := 0; i <= n; i++ {
+		automata.SetEndState(fmt.Sprintf("s%d", i))
+		if i < n {
+			for _, r := range letters {
+				automata.SetTransition(fmt.Sprintf("s%d", i), fmt.Sprintf("s%d", i+1), string(r))
+			}
+		}
+	}
+	automata.SetStartState("s0")
+	return automata
+}
+
+func TestProductEmail(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	short := maxLenDFA("abcdefghijklmnopqrstuvwxyz0123456789.@_-", 10)
+
+	inter := dfa.Intersect(email, short)
+	diff := dfa.Difference(email, short)
+	for _, tc := range []struct {
+		s           string
+		inter, diff bool
+	}{
+		{"ab@cd.ru", true, false},
+		{"ab@cd.com", true, false},
+		{"abcdef@cd.com", false, true},
+		{"abc", false, false},
+	} {
+		if got := inter.Accepts(tc.s); got != tc.inter {
+			t.Errorf("Intersect.Accepts(%q) = %v", tc.s, got)
+		}
+		if got := diff.Accepts(tc.s); got != tc.diff {
+			t.Errorf("Difference.Accepts(%q) = %v", tc.s, got)
+		}
+	}
+}
+
+func TestProductAlphabets(t *testing.T) {
+	as := maxLenDFA("a", 2)
+	bs := maxLenDFA("b", 1)
+
+	union := dfa.Union(as, bs)
+	inter := dfa.Intersect(as, bs)
+	sym := dfa.SymmetricDifference(as, bs)
+	for _, tc := range []struct {
+		s                 string
+		union, inter, sym bool
+	}{
+		{"", true, true, false},
+		{"a", true, false, true},
+		{"aa", true, false, true},
+		{"aaa", false, false, false},
+		{"b", true, false, true},
+		{"ab", false, false, false},
+	} {
+		if got := union.Accepts(tc.s); got != tc.union {
+			t.Errorf("Union.Accepts(%q) = %v", tc.s, got)
+		}
+		if got := inter.Accepts(tc.s); got != tc.inter {
+			t.Errorf("Intersect.Accepts(%q) = %v", tc.s, got)
+		}
+		if got := sym.Accepts(tc.s); got != tc.sym {
+			t.Errorf("SymmetricDifference.Accepts(%q) = %v", tc.s, got)
+		}
+	}
+	if union.FindLetterByName("a") == nil || union.FindLetterByName("b") == nil {
+		t.Errorf("алфавит произведения должен быть объединением алфавитов")
+	}
+}
+
+func TestProductCommaNames(t *testing.T) {
+	// пары (p, "q,r") и ("p,q", r) имеют одинаковую запись (p,q,r)
+	build := func(start, end string) *dfa.DFA {
+		automata := dfa.NewDFA(0)
+		automata.AddState(start, false)
+		automata.AddState(end, true)
+		automata.AddLetter("a")
+		automata.SetTransition(start, end, "a")
+		automata.SetStartState(start)
+		return automata
+	}
+	inter := dfa.Intersect(build("p", "p,q"), build("q,r", "r"))
+	if !inter.Accepts("a") || inter.Accepts("") || inter.Accepts("aa") {
+		t.Errorf("Intersect распознаёт не тот язык")
+	}
+	first, second := inter.FindStateByName("(p,q,r)"), inter.FindStateByName("(p,q,r)1")
+	if first == nil || second == nil || first == second {
+		t.Errorf("разные пары должны получить разные имена: %v, %v", first, second)
+	}
+	if inter.GetStartState() != first {
+		t.Errorf("начальное состояние %v, ожидалось (p,q,r)", inter.GetStartState())
+	}
+}
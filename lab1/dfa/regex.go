@@ -0,0 +1,310 @@
+package dfa
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// emptySetRegex — регулярное выражение, которому не соответствует ни одна строка
+const emptySetRegex = `[^\x00-\x{10FFFF}]`
+
+// ToRegex возвращает регулярное выражение в синтаксисе пакета regexp, задающее язык ДКА.
+// Выражение строится методом исключения состояний; на каждом шаге применяются упрощения
+// (объединение одиночных символов в классы, r|r = r, (r*)* = r*, (ε|r)* = r* и др.),
+// поэтому результат обычно близок к написанному вручную. Выражение описывает строку целиком:
+// для поиска в тексте его нужно окружить якорями ^ и $
+func (d *DFA) ToRegex() string {
+	t := d.table().trim()
+	if t.start < 0 || !t.live()[t.start] {
+		return emptySetRegex
+	}
+
+	n := len(t.names)
+	src, dst := n, n+1
+	edge := make([][]*rx, n+2) // edge[i][j] — выражение на дуге из i в j или nil
+	for i := range edge {
+		edge[i] = make([]*rx, n+2)
+	}
+	add := func(i, j int, r *rx) {
+		if edge[i][j] == nil {
+			edge[i][j] = r
+		} else {
+			edge[i][j] = rxAlt(edge[i][j], r)
+		}
+	}
+	add(src, t.start, rxEpsilon)
+	for s, row := range t.delta {
+		for l, to := range row {
+			if to >= 0 {
+				add(s, to, rxSymbol(t.syms[l]))
+			}
+		}
+		if t.term[s] {
+			add(s, dst, rxEpsilon)
+		}
+	}
+
+	removed := make([]bool, n+2)
+	for k := 0; k < n; k++ {
+		// исключается состояние с наименьшим произведением числа входящих и исходящих дуг
+		best, bestCost := -1, 0
+		for s := 0; s < n; s++ {
+			if removed[s] {
+				continue
+			}
+			in, out := 0, 0
+			for i := range edge {
+				if i != s && !removed[i] && edge[i][s] != nil {
+					in++
+				}
+				if i != s && !removed[i] && edge[s][i] != nil {
+					out++
+				}
+			}
+			if cost := in * out; best < 0 || cost < bestCost {
+				best, bestCost = s, cost
+			}
+		}
+
+		loop := rxEpsilon
+		if edge[best][best] != nil {
+			loop = rxStar(edge[best][best])
+		}
+		for i := range edge {
+			if i == best || removed[i] || edge[i][best] == nil {
+				continue
+			}
+			for j := range edge {
+				if j == best || removed[j] || edge[best][j] == nil {
+					continue
+				}
+				add(i, j, rxCat(edge[i][best], loop, edge[best][j]))
+			}
+		}
+		removed[best] = true
+	}
+
+	if edge[src][dst] == nil {
+		return emptySetRegex
+	}
+	return edge[src][dst].String()
+}
+
+// rxKind — вид узла регулярного выражения
+type rxKind int
+
+const (
+	rxKindEpsilon rxKind = iota // пустая цепочка
+	rxKindClass                 // одна из рун класса
+	rxKindLiteral               // символ, имя которого состоит из нескольких рун
+	rxKindCat                   // конкатенация
+	rxKindAlt                   // объединение
+	rxKindStar                  // итерация
+)
+
+// rx — узел регулярного выражения. Узлы строятся только упрощающими конструкторами
+// rxLetter, rxCat, rxAlt и rxStar и после построения не изменяются
+type rx struct {
+	kind  rxKind
+	class Class  // руны класса
+	lit   string // имя многосимвольной буквы
+	subs  []*rx  // операнды конкатенации и объединения, операнд итерации
+	key   string // каноническая запись, по которой сравниваются выражения
+}
+
+var rxEpsilon = &rx{kind: rxKindEpsilon}
+
+// rxSymbol возвращает выражение для символа плотного представления: буквы или диапазона рун
+func rxSymbol(sym symbol) *rx {
+	if sym.isSpan {
+		return rxNew(&rx{kind: rxKindClass, class: Class{sym.span}})
+	}
+	if r, ok := singleRune(sym.name); ok {
+		return rxNew(&rx{kind: rxKindClass, class: Class{{r, r}}})
+	}
+	return rxNew(&rx{kind: rxKindLiteral, lit: sym.name})
+}
+
+// rxNew вычисляет каноническую запись узла
+func rxNew(r *rx) *rx {
+	r.key = r.String()
+	return r
+}
+
+// rxCat возвращает конкатенацию выражений без пустых цепочек и вложенных конкатенаций
+func rxCat(parts ...*rx) *rx {
+	var subs []*rx
+	for _, p := range parts {
+		switch p.kind {
+		case rxKindEpsilon:
+		case rxKindCat:
+			subs = append(subs, p.subs...)
+		default:
+			subs = append(subs, p)
+		}
+	}
+	switch len(subs) {
+	case 0:
+		return rxEpsilon
+	case 1:
+		return subs[0]
+	}
+	return rxNew(&rx{kind: rxKindCat, subs: subs})
+}
+
+// rxAlt возвращает объединение выражений: одиночные символы сливаются в один класс,
+// повторы удаляются, а пустая цепочка поглощается итерацией
+func rxAlt(parts ...*rx) *rx {
+	var flat []*rx
+	for _, p := range parts {
+		if p.kind == rxKindAlt {
+			flat = append(flat, p.subs...)
+		} else {
+			flat = append(flat, p)
+		}
+	}
+
+	eps := false
+	var class Class
+	seen := map[string]bool{}
+	var subs []*rx
+	for _, p := range flat {
+		switch {
+		case p.kind == rxKindEpsilon:
+			eps = true
+		case p.kind == rxKindClass:
+			class = class.Union(p.class)
+		case !seen[p.key]:
+			seen[p.key] = true
+			subs = append(subs, p)
+		}
+	}
+	if len(class) > 0 {
+		subs = append(subs, rxNew(&rx{kind: rxKindClass, class: class}))
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].key < subs[j].key })
+
+	if eps {
+		// ε|r* = r*
+		for _, p := range subs {
+			if p.kind == rxKindStar {
+				eps = false
+			}
+		}
+	}
+	if eps {
+		subs = append([]*rx{rxEpsilon}, subs...)
+	}
+	switch len(subs) {
+	case 0:
+		return rxEpsilon
+	case 1:
+		return subs[0]
+	}
+	return rxNew(&rx{kind: rxKindAlt, subs: subs})
+}
+
+// rxStar возвращает итерацию выражения: ε* = ε, (r*)* = r*, (ε|r)* = r*
+func rxStar(r *rx) *rx {
+	switch r.kind {
+	case rxKindEpsilon, rxKindStar:
+		return r
+	case rxKindAlt:
+		if r.subs[0].kind == rxKindEpsilon {
+			return rxStar(rxAlt(r.subs[1:]...))
+		}
+	}
+	return rxNew(&rx{kind: rxKindStar, subs: []*rx{r}})
+}
+
+// String возвращает запись выражения в синтаксисе пакета regexp
+func (r *rx) String() string {
+	var b strings.Builder
+	r.write(&b)
+	return b.String()
+}
+
+// atomic возвращает true, если к записи выражения можно применить постфиксный оператор без скобок
+func (r *rx) atomic() bool {
+	switch r.kind {
+	case rxKindClass:
+		return true
+	case rxKindLiteral:
+		return utf8.RuneCountInString(regexp.QuoteMeta(r.lit)) == 1
+	}
+	return false
+}
+
+// write записывает выражение в b
+func (r *rx) write(b *strings.Builder) {
+	switch r.kind {
+	case rxKindEpsilon:
+	case rxKindClass:
+		writeClass(b, r.class)
+	case rxKindLiteral:
+		b.WriteString(regexp.QuoteMeta(r.lit))
+	case rxKindCat:
+		for _, p := range r.subs {
+			if p.kind == rxKindAlt && p.subs[0].kind != rxKindEpsilon {
+				b.WriteByte('(')
+				p.write(b)
+				b.WriteByte(')')
+			} else {
+				p.write(b)
+			}
+		}
+	case rxKindAlt:
+		if r.subs[0].kind == rxKindEpsilon {
+			// ε|r записывается как r?
+			rest := rxAlt(r.subs[1:]...)
+			writePostfix(b, rest, '?')
+			return
+		}
+		for i, p := range r.subs {
+			if i > 0 {
+				b.WriteByte('|')
+			}
+			p.write(b)
+		}
+	case rxKindStar:
+		writePostfix(b, r.subs[0], '*')
+	}
+}
+
+// writePostfix записывает выражение с постфиксным оператором, при необходимости в скобках
+func writePostfix(b *strings.Builder, r *rx, op byte) {
+	if r.atomic() {
+		r.write(b)
+	} else {
+		b.WriteByte('(')
+		r.write(b)
+		b.WriteByte(')')
+	}
+	b.WriteByte(op)
+}
+
+// writeClass записывает класс символов; класс из одной руны записывается самой руной
+func writeClass(b *strings.Builder, c Class) {
+	if len(c) == 1 && c[0].Lo == c[0].Hi {
+		b.WriteString(regexp.QuoteMeta(string(c[0].Lo)))
+		return
+	}
+	b.WriteString(c.String())
+}
+
+// writeClassRune записывает руну внутри класса символов, экранируя служебные символы
+func writeClassRune(b *strings.Builder, r rune) {
+	switch {
+	case strings.ContainsRune(`\[]^-`, r):
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	case unicode.IsPrint(r):
+		b.WriteRune(r)
+	default:
+		fmt.Fprintf(b, `\x{%x}`, r)
+	}
+}
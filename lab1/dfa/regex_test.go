@@ -0,0 +1,60 @@
+package dfa_test
+
+import (
+	"dfa"
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+func TestToRegexEmail(t *testing.T) {
+	got := dfa.NewEmailDFA().ToRegex()
+	want := `[a-z][\-0-9_a-z]*@[\-0-9_a-z]*\.(com|ru)`
+	if got != want {
+		t.Errorf("ToRegex() = %s, ожидалось %s", got, want)
+	}
+}
+
+func TestToRegexRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	inputs := allStrings("ab.", 6)
+	for k := 0; k < 200; k++ {
+		automata := randomDFA(rng, 2+rng.Intn(4), "ab.")
+		expr := automata.ToRegex()
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			t.Fatalf("ToRegex() = %s: %v", expr, err)
+		}
+		for _, s := range inputs {
+			if re.MatchString(s) != automata.Accepts(s) {
+				t.Fatalf("ToRegex() = %s: расхождение на %q", expr, s)
+			}
+		}
+	}
+}
+
+func TestToRegexSpecial(t *testing.T) {
+	empty := dfa.NewDFA(1)
+	empty.SetStartState("s0")
+	if re := regexp.MustCompile("^(?:" + empty.ToRegex() + ")$"); re.MatchString("") {
+		t.Errorf("выражение пустого языка не должно ничему соответствовать")
+	}
+
+	epsilon := dfa.NewDFA(1)
+	epsilon.SetStartState("s0")
+	epsilon.SetEndState("s0")
+	if got := epsilon.ToRegex(); got != "" {
+		t.Errorf("выражение языка из пустой цепочки = %q", got)
+	}
+
+	words := dfa.NewDFA(2)
+	words.AddLetter("com")
+	words.AddLetter("]")
+	words.SetTransition("s0", "s1", "com")
+	words.SetTransition("s1", "s1", "]")
+	words.SetStartState("s0")
+	words.SetEndState("s1")
+	if got := words.ToRegex(); got != `com\]*` {
+		t.Errorf("ToRegex() = %s", got)
+	}
+}
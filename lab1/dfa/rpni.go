@@ -0,0 +1,195 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LearnRPNI строит ДКА, принимающий все строки positive и отвергающий все строки negative,
+// алгоритмом RPNI. Строки читаются по одной руне, как в Accepts; алфавит результата — руны выборок.
+// Сначала строится префиксное дерево выборок, затем состояния объединяются в порядке красных и синих:
+// наименьшее в порядке длины и лексикографии синее состояние сливается с первым красным, с которым
+// слияние не нарушает ни одной выборки, а если такого нет — становится красным.
+// Состояния результата называются s0, s1, ... в порядке, в котором они стали красными.
+// Возвращает nil, если одна и та же строка есть в обеих выборках
+func LearnRPNI(positive, negative []string) *DFA {
+	p := newPrefixTree()
+	for _, s := range positive {
+		if !p.add(s, labelPositive) {
+			return nil
+		}
+	}
+	for _, s := range negative {
+		if !p.add(s, labelNegative) {
+			return nil
+		}
+	}
+	rank := p.shortlex()
+
+	red := []int{0}
+	isRed := map[int]bool{0: true}
+	for {
+		blue := -1
+		for _, r := range red {
+			for _, c := range p.next[r] {
+				c = p.find(c)
+				if !isRed[c] && (blue < 0 || rank[c] < rank[blue]) {
+					blue = c
+				}
+			}
+		}
+		if blue < 0 {
+			break
+		}
+		merged := false
+		for _, r := range red {
+			if p.merge(r, blue) {
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			red = append(red, blue)
+			isRed[blue] = true
+		}
+	}
+	return p.build(red)
+}
+
+// Пометки узлов префиксного дерева
+const (
+	labelNone     = iota // строка узла не встречается в выборках
+	labelPositive        // строка узла есть в положительной выборке
+	labelNegative        // строка узла есть в отрицательной выборке
+)
+
+// prefixTree — префиксное дерево выборок, узлы которого объединяются системой
+// непересекающихся множеств с возможностью отмены
+type prefixTree struct {
+	next   []map[rune]int // переходы узлов; у представителя множества — переходы объединения
+	label  []int          // пометки; у представителя множества — пометка объединения
+	parent []int          // родитель в системе непересекающихся множеств
+	undo   []func()       // действия, отменяющие изменения неудачного слияния
+}
+
+// newPrefixTree создает префиксное дерево из одного корня
+func newPrefixTree() *prefixTree {
+	p := &prefixTree{}
+	p.node()
+	return p
+}
+
+// node добавляет узел и возвращает его номер
+func (p *prefixTree) node() int {
+	p.next = append(p.next, map[rune]int{})
+	p.label = append(p.label, labelNone)
+	p.parent = append(p.parent, len(p.parent))
+	return len(p.parent) - 1
+}
+
+// add добавляет строку с пометкой. Возвращает false, если строка уже помечена иначе
+func (p *prefixTree) add(s string, label int) bool {
+	n := 0
+	for _, r := range s {
+		c, ok := p.next[n][r]
+		if !ok {
+			c = p.node()
+			p.next[n][r] = c
+		}
+		n = c
+	}
+	if p.label[n] != labelNone && p.label[n] != label {
+		return false
+	}
+	p.label[n] = label
+	return true
+}
+
+// shortlex возвращает ранг каждого узла при обходе в ширину с переходами по возрастанию рун,
+// то есть в порядке длины и лексикографии строк узлов
+func (p *prefixTree) shortlex() []int {
+	rank := make([]int, len(p.next))
+	queue := []int{0}
+	for i := 0; i < len(queue); i++ {
+		n := queue[i]
+		rank[n] = i
+		for _, r := range sortedRunes(p.next[n]) {
+			queue = append(queue, p.next[n][r])
+		}
+	}
+	return rank
+}
+
+// sortedRunes возвращает руны переходов по возрастанию
+func sortedRunes(next map[rune]int) []rune {
+	runes := make([]rune, 0, len(next))
+	for r := range next {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// find возвращает представителя множества узла
+func (p *prefixTree) find(n int) int {
+	for p.parent[n] != n {
+		n = p.parent[n]
+	}
+	return n
+}
+
+// merge сливает синий узел b с красным узлом r и, чтобы автомат остался детерминированным,
+// рекурсивно сливает узлы переходов по одинаковым рунам. Если при этом сливаются узлы
+// с противоположными пометками, все изменения отменяются и возвращается false
+func (p *prefixTree) merge(r, b int) bool {
+	p.undo = p.undo[:0]
+	pairs := [][2]int{{r, b}}
+	for len(pairs) > 0 {
+		x, y := p.find(pairs[len(pairs)-1][0]), p.find(pairs[len(pairs)-1][1])
+		pairs = pairs[:len(pairs)-1]
+		if x == y {
+			continue
+		}
+		if p.label[x] != labelNone && p.label[y] != labelNone && p.label[x] != p.label[y] {
+			for i := len(p.undo) - 1; i >= 0; i-- {
+				p.undo[i]()
+			}
+			return false
+		}
+		p.parent[y] = x
+		p.undo = append(p.undo, func() { p.parent[y] = y })
+		if p.label[x] == labelNone && p.label[y] != labelNone {
+			p.label[x] = p.label[y]
+			p.undo = append(p.undo, func() { p.label[x] = labelNone })
+		}
+		for c, ty := range p.next[y] {
+			if tx, ok := p.next[x][c]; ok {
+				pairs = append(pairs, [2]int{tx, ty})
+				continue
+			}
+			p.next[x][c] = ty
+			p.undo = append(p.undo, func() { delete(p.next[x], c) })
+		}
+	}
+	return true
+}
+
+// build создает ДКА по красным узлам: красные узлы — представители всех достижимых множеств
+func (p *prefixTree) build(red []int) *DFA {
+	d := NewDFA(0)
+	states := make(map[int]string, len(red))
+	for i, r := range red {
+		states[r] = fmt.Sprintf("s%d", i)
+		d.AddState(states[r], p.label[r] == labelPositive)
+	}
+	for _, r := range red {
+		for _, c := range sortedRunes(p.next[r]) {
+			if d.FindLetterByName(string(c)) == nil {
+				d.AddLetter(string(c))
+			}
+			d.SetTransition(states[r], states[p.find(p.next[r][c])], string(c))
+		}
+	}
+	d.SetStartState("s0")
+	return d
+}
@@ -0,0 +1,68 @@
+package dfa_test
+
+import (
+	"dfa"
+	"testing"
+)
+
+// split делит строки на принимаемые и отвергаемые автоматом
+func split(d *dfa.DFA, words []string) (positive, negative []string) {
+	for _, w := range words {
+		if d.Accepts(w) {
+			positive = append(positive, w)
+		} else {
+			negative = append(negative, w)
+		}
+	}
+	return positive, negative
+}
+
+func TestLearnRPNI(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		target *dfa.DFA
+		words  []string
+		states int
+	}{
+		{"a mod 3", modCountDFA(3), allStrings("ab", 6), 4},
+		{"идентификатор", identifierDFA().Minimize(), allStrings("a1ё", 4), 3},
+	} {
+		positive, negative := split(c.target, c.words)
+		learned := dfa.LearnRPNI(positive, negative)
+		if learned == nil {
+			t.Fatalf("%s: LearnRPNI вернул nil", c.name)
+		}
+		for _, w := range positive {
+			if !learned.Accepts(w) {
+				t.Errorf("%s: положительная строка %q отвергнута", c.name, w)
+			}
+		}
+		for _, w := range negative {
+			if learned.Accepts(w) {
+				t.Errorf("%s: отрицательная строка %q принята", c.name, w)
+			}
+		}
+		if n := learned.Minimize().Compile().NumStates(); n != c.states {
+			t.Errorf("%s: %d состояний, ожидалось %d", c.name, n, c.states)
+		}
+		if learned.GetStartState().String() != "s0" {
+			t.Errorf("%s: начальное состояние %v", c.name, learned.GetStartState())
+		}
+	}
+}
+
+func TestLearnRPNIGeneralizes(t *testing.T) {
+	// по нескольким примерам восстанавливается язык (ab)*
+	learned := dfa.LearnRPNI([]string{"", "ab", "abab"}, []string{"a", "b", "aa", "ba", "bb", "aba", "abb"})
+	for s, ok := range map[string]bool{"ababab": true, "abababab": true, "abba": false, "ababa": false} {
+		if learned.Accepts(s) != ok {
+			t.Errorf("Accepts(%q) = %v", s, !ok)
+		}
+	}
+	if dfa.LearnRPNI([]string{"ab", "c"}, []string{"c"}) != nil {
+		t.Errorf("противоречивые выборки должны давать nil")
+	}
+	if empty := dfa.LearnRPNI(nil, nil); empty == nil || empty.Accepts("") {
+		t.Errorf("пустые выборки: %v", empty)
+	}
+}
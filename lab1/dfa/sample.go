@@ -0,0 +1,59 @@
+package dfa
+
+import (
+	"math/big"
+	"math/rand"
+)
+
+// ShortestWord возвращает кратчайшую цепочку языка ДКА, а из нескольких кратчайших —
+// лексикографически наименьшую. Второе значение равно false, если язык пуст
+func (d *DFA) ShortestWord() (string, bool) {
+	for w := range d.Words(-1) {
+		return w, true
+	}
+	return "", false
+}
+
+// Sample возвращает цепочку языка ДКА длиной length символов, выбранную равновероятно
+// среди всех таких цепочек. Выбор использует число путей до заключительных состояний
+// из каждого состояния, поэтому не зависит от порядка символов алфавита.
+// Второе значение равно false, если цепочек такой длины нет
+func (d *DFA) Sample(rng *rand.Rand, length int) (string, bool) {
+	t := d.table().trim()
+	if length < 0 || t.start < 0 {
+		return "", false
+	}
+	c := t.counts(length)
+	total := c[length][t.start]
+	if total.Sign() == 0 {
+		return "", false
+	}
+
+	// номер цепочки среди всех цепочек длины length в лексикографическом порядке
+	x := new(big.Int).Rand(rng, total)
+	word := make([]pick, length)
+	s := t.start
+	for i := range word {
+		r := length - i - 1
+		for l, to := range t.delta[s] {
+			if to < 0 {
+				continue
+			}
+			if w := t.weigh(l, c[r][to]); x.Cmp(w) >= 0 {
+				x.Sub(x, w)
+				continue
+			}
+			// номер руны диапазона — частное, номер продолжения — остаток
+			k := new(big.Int)
+			x.QuoRem(x, c[r][to], k)
+			word[i] = pick{l: l}
+			if sym := t.syms[l]; sym.isSpan {
+				word[i].r = sym.span.Lo + rune(x.Int64())
+			}
+			x = k
+			s = to
+			break
+		}
+	}
+	return t.spell(word), true
+}
@@ -0,0 +1,68 @@
+package dfa_test
+
+import (
+	"dfa"
+	"math/rand"
+	"testing"
+)
+
+func TestShortestWord(t *testing.T) {
+	if w, ok := dfa.NewEmailDFA().ShortestWord(); !ok || w != "a@.ru" {
+		t.Errorf("ShortestWord() = %q, %v", w, ok)
+	}
+	empty := dfa.NewDFA(1)
+	empty.SetStartState("s0")
+	if _, ok := empty.ShortestWord(); ok {
+		t.Errorf("у пустого языка нет кратчайшей цепочки")
+	}
+	if _, ok := deadLoopDFA().ShortestWord(); ok {
+		t.Errorf("у пустого языка с петлёй в начальном состоянии нет кратчайшей цепочки")
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	// {aa, ab, ac, ba}: при выборе первого символа наугад "ba" выпадала бы в половине случаев
+	automata := dfa.NewDFA(4)
+	for _, l := range []string{"a", "b", "c"} {
+		automata.AddLetter(l)
+		automata.SetTransition("s1", "s3", l)
+	}
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s0", "s2", "b")
+	automata.SetTransition("s2", "s3", "a")
+	automata.SetStartState("s0")
+	automata.SetEndState("s3")
+
+	rng := rand.New(rand.NewSource(1))
+	freq := map[string]int{}
+	const n = 3000
+	for i := 0; i < n; i++ {
+		w, ok := automata.Sample(rng, 2)
+		if !ok || !automata.Accepts(w) {
+			t.Fatalf("Sample вернул %q, %v", w, ok)
+		}
+		freq[w]++
+	}
+	if len(freq) != 4 {
+		t.Fatalf("ожидались четыре разные цепочки, получено %v", freq)
+	}
+	for w, k := range freq {
+		if k < n/4-150 || k > n/4+150 {
+			t.Errorf("частота %q = %d из %d", w, k, n)
+		}
+	}
+}
+
+func TestSampleEmail(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		w, ok := email.Sample(rng, 20)
+		if !ok || len(w) != 20 || !email.Accepts(w) {
+			t.Fatalf("Sample вернул %q, %v", w, ok)
+		}
+	}
+	if _, ok := email.Sample(rng, 4); ok {
+		t.Errorf("адресов длины 4 не существует")
+	}
+}
@@ -0,0 +1,131 @@
+package dfa
+
+import "unicode/utf8"
+
+// thread — попытка совпадения: текущее состояние автомата и байт, с которого начато чтение
+type thread struct {
+	state int
+	start int
+}
+
+// find ищет самое левое совпадение, начинающееся не раньше байта from.
+// Из совпадений с одним началом выбирается самое длинное.
+// Строка читается один раз: с каждого байта-начала руны запускается попытка совпадения, а попытки,
+// пришедшие в одно состояние, объединяются в самую раннюю, так как их продолжения совпадают.
+// Поэтому одновременно идёт не больше попыток, чем состояний, и поиск занимает O(n·|Q|)
+// для n байт, прочитанных до конца самого длинного совпадения, вместо O(n²) при проверке каждого начала.
+// Возвращает границы совпадения или -1, -1, если совпадений нет
+func (m *Matcher) find(s string, from int) (int, int) {
+	if m.start < 0 {
+		return -1, -1
+	}
+	owner := make([]int, m.NumStates()) // owner[q] — номер шага, на котором q уже занято попыткой
+	var threads, next []thread          // попытки по возрастанию начала
+	start, end := -1, -1
+	for i, step := from, 1; ; step++ {
+		if start < 0 && owner[m.start] != step {
+			owner[m.start] = step
+			threads = append(threads, thread{int(m.start), i})
+		}
+		for _, t := range threads {
+			if m.accept[t.state] {
+				if start < 0 || t.start < start {
+					start = t.start
+				}
+				if t.start == start {
+					end = i
+				}
+				break // более поздние начала хуже найденного
+			}
+		}
+		if start >= 0 {
+			k := 0
+			for k < len(threads) && threads[k].start <= start {
+				k++
+			}
+			threads = threads[:k] // попытки, начатые позже найденного совпадения, не нужны
+		}
+		if i == len(s) || (start >= 0 && len(threads) == 0) {
+			return start, end
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		next = next[:0]
+		for _, t := range threads {
+			q := m.Step(t.state, r)
+			if q >= 0 && owner[q] != step+1 {
+				owner[q] = step + 1
+				next = append(next, thread{q, t.start})
+			}
+		}
+		threads, next = next, threads
+	}
+}
+
+// FindIndex возвращает байтовые границы самого левого из самых длинных совпадений
+// с языком автомата в строке s или nil, если совпадений нет
+func (m *Matcher) FindIndex(s string) []int {
+	start, end := m.find(s, 0)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindAllIndex возвращает байтовые границы не более n непересекающихся совпадений
+// (всех совпадений, если n < 0). Совпадения ищутся слева направо, из совпадений
+// с одним началом выбирается самое длинное. Пустое совпадение сразу после
+// предыдущего совпадения пропускается
+func (m *Matcher) FindAllIndex(s string, n int) [][]int {
+	var out [][]int
+	prevEnd := -1
+	for pos := 0; pos <= len(s) && (n < 0 || len(out) < n); {
+		start, end := m.find(s, pos)
+		if start < 0 {
+			break
+		}
+		if end > start || start != prevEnd {
+			out = append(out, []int{start, end})
+			prevEnd = end
+		}
+		if end > start {
+			pos = end
+			continue
+		}
+		if end == len(s) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[end:])
+		pos = end + size
+	}
+	return out
+}
+
+// FindAllString возвращает тексты не более n непересекающихся совпадений
+// (всех совпадений, если n < 0) в порядке их следования в строке
+func (m *Matcher) FindAllString(s string, n int) []string {
+	var out []string
+	for _, loc := range m.FindAllIndex(s, n) {
+		out = append(out, s[loc[0]:loc[1]])
+	}
+	return out
+}
+
+// FindIndex возвращает байтовые границы самого левого из самых длинных совпадений
+// с языком ДКА в строке s или nil, если совпадений нет
+func (d *DFA) FindIndex(s string) []int {
+	return d.Compile().FindIndex(s)
+}
+
+// FindAllIndex возвращает байтовые границы не более n непересекающихся совпадений
+// с языком ДКА (всех совпадений, если n < 0)
+func (d *DFA) FindAllIndex(s string, n int) [][]int {
+	return d.Compile().FindAllIndex(s, n)
+}
+
+// FindAllString возвращает тексты не более n непересекающихся совпадений
+// с языком ДКА (всех совпадений, если n < 0)
+func (d *DFA) FindAllString(s string, n int) []string {
+	return d.Compile().FindAllString(s, n)
+}
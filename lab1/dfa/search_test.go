@@ -0,0 +1,126 @@
+package dfa_test
+
+import (
+	"dfa"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFindAllEmails(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	text := "Пишите на vladimirov_d1ma@mail.ru или support@site.com, но не на bad@site.org и @x.ru."
+
+	got := email.FindAllString(text, -1)
+	want := []string{"vladimirov_d1ma@mail.ru", "support@site.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllString = %q, ожидалось %q", got, want)
+	}
+
+	loc := email.FindIndex(text)
+	if loc == nil || text[loc[0]:loc[1]] != want[0] {
+		t.Errorf("FindIndex = %v", loc)
+	}
+	if got := email.FindAllIndex(text, 1); len(got) != 1 {
+		t.Errorf("FindAllIndex с n = 1 вернул %d совпадений", len(got))
+	}
+	if email.FindIndex("нет адресов") != nil {
+		t.Errorf("FindIndex должен возвращать nil, если совпадений нет")
+	}
+}
+
+func TestFindAllLikeRegexp(t *testing.T) {
+	// a*b? — с пустыми совпадениями
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.SetTransition("s0", "s0", "a")
+	automata.SetTransition("s0", "s1", "b")
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+	automata.SetEndState("s1")
+
+	re := regexp.MustCompile("a*b?")
+	re.Longest()
+	for _, s := range []string{"", "baaab", "xaxbbx", "ääab", "abab"} {
+		got := automata.FindAllIndex(s, -1)
+		want := re.FindAllStringIndex(s, -1)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllIndex(%q) = %v, regexp: %v", s, got, want)
+		}
+	}
+}
+
+// randomDFA строит случайный неполный ДКА с n состояниями над алфавитом letters
+func randomDFA(rng *rand.Rand, n int, letters string) *dfa.DFA {
+	automata := dfa.NewDFA(n)
+	for _, r := range letters {
+		automata.AddLetter(string(r))
+	}
+	for i := 0; i < n; i++ {
+		for _, r := range letters {
+			if rng.Intn(4) > 0 {
+				automata.SetTransition(fmt.Sprintf("s%d", i), fmt.Sprintf("s%d", rng.Intn(n)), string(r))
+			}
+		}
+		if rng.Intn(3) == 0 {
+			automata.SetEndState(fmt.Sprintf("s%d", i))
+		}
+	}
+	automata.SetStartState("s0")
+	return automata
+}
+
+// allStrings возвращает все строки над алфавитом letters длиной не более n
+func allStrings(letters string, n int) []string {
+	out := []string{""}
+	level := []string{""}
+	for i := 0; i < n; i++ {
+		var next []string
+		for _, s := range level {
+			for _, r := range letters {
+				next = append(next, s+string(r))
+			}
+		}
+		out = append(out, next...)
+		level = next
+	}
+	return out
+}
+
+// naiveFind находит самое левое и самое длинное вхождение перебором всех подстрок
+func naiveFind(automata *dfa.DFA, s string) []int {
+	for i := 0; i <= len(s); i++ {
+		for j := len(s); j >= i; j-- {
+			if automata.Accepts(s[i:j]) {
+				return []int{i, j}
+			}
+		}
+	}
+	return nil
+}
+
+func TestFindRandomLikeNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	inputs := allStrings("abc", 5)
+	for k := 0; k < 200; k++ {
+		automata := randomDFA(rng, 1+rng.Intn(4), "ab")
+		for _, s := range inputs {
+			if got, want := automata.FindIndex(s), naiveFind(automata, s); !reflect.DeepEqual(got, want) {
+				t.Fatalf("автомат %d: FindIndex(%q) = %v, перебор: %v", k, s, got, want)
+			}
+		}
+	}
+}
+
+func TestFindLongRun(t *testing.T) {
+	// в длинном слове без @ автомат адресов долго остаётся живым, не принимая;
+	// поиск читает строку один раз, а не с каждого начала
+	text := strings.Repeat("abc1_", 40000) + " a@b.ru"
+	if got := dfa.NewEmailDFA().FindAllString(text, -1); !reflect.DeepEqual(got, []string{"a@b.ru"}) {
+		t.Errorf("FindAllString = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package dfa
+
+// Session — курсор выполнения ДКА со своим текущим состоянием.
+// Сессии не изменяют автомат, поэтому один ДКА может одновременно обслуживать
+// сессии из разных горутин, пока сам автомат никто не изменяет.
+// Одна сессия не предназначена для одновременного использования несколькими горутинами
+type Session struct {
+	dfa      *DFA     // автомат, по которому выполняется сессия
+	current  *State   // текущее состояние сессии
+	observer Observer // наблюдатель за выполнением или nil
+}
+
+// NewSession создает новую сессию, текущее состояние которой равно начальному состоянию ДКА.
+// Сессия получает наблюдатель, подключённый к ДКА
+func (d *DFA) NewSession() *Session {
+	return &Session{dfa: d, current: d.start, observer: d.observer}
+}
+
+// SetObserver подключает к сессии наблюдатель вместо унаследованного от ДКА; nil отключает наблюдение
+func (s *Session) SetObserver(o Observer) {
+	s.observer = o
+}
+
+// Reset сбрасывает текущее состояние сессии в начальное состояние ДКА
+func (s *Session) Reset() {
+	s.current = s.dfa.start
+}
+
+// Current возвращает текущее состояние сессии или nil, если переход оказался невозможен
+func (s *Session) Current() *State {
+	return s.current
+}
+
+// Accepting возвращает true, если текущее состояние сессии заключительное
+func (s *Session) Accepting() bool {
+	return s.current != nil && s.current.term
+}
+
+// Transition выполняет переход из текущего состояния сессии по заданному символу и возвращает новое текущее состояние.
+// Если перехода по символу нет, а его имя состоит из одной руны, используется переход по диапазону рун.
+// Если перехода нет, сессия переходит в тупик: текущее состояние становится nil
+func (s *Session) Transition(by *Letter) *State {
+	if s.current == nil || by == nil {
+		s.current = nil
+		return nil
+	}
+	from := s.current
+	s.current = s.dfa.next(from, by)
+	if s.current != nil && s.observer != nil {
+		s.observer.OnTransition(from, by, s.current)
+	}
+	return s.current
+}
+
+// Step выполняет переход по символу с заданным именем, а если такого символа нет в алфавите —
+// по диапазону, содержащему руну, из которой состоит имя
+func (s *Session) Step(name string) *State {
+	if by := s.dfa.FindLetterByName(name); by != nil {
+		return s.Transition(by)
+	}
+	if s.current != nil {
+		from := s.current
+		s.current = s.dfa.runeTarget(from, name)
+		if s.current != nil && s.observer != nil {
+			s.observer.OnTransition(from, NewLetter(name), s.current)
+		}
+	}
+	return s.current
+}
+
+// CheckChain сбрасывает сессию и проверяет цепочку символов на принадлежность языку ДКА
+func (s *Session) CheckChain(chain []*Letter) bool {
+	s.Reset()
+	for _, l := range chain {
+		from := s.current
+		if s.Transition(l) == nil {
+			return s.reject(from, l)
+		}
+	}
+	return s.finish()
+}
+
+// Accepts сбрасывает сессию и проверяет строку на принадлежность языку ДКА
+func (s *Session) Accepts(str string) bool {
+	s.Reset()
+	for _, r := range str {
+		from := s.current
+		if s.Step(string(r)) == nil {
+			if by := s.dfa.FindLetterByName(string(r)); by != nil {
+				return s.reject(from, by)
+			}
+			return s.reject(from, NewLetter(string(r)))
+		}
+	}
+	return s.finish()
+}
+
+// reject сообщает наблюдателю об отказе в состоянии at по символу by и возвращает false
+func (s *Session) reject(at *State, by *Letter) bool {
+	if s.observer != nil {
+		if at == nil {
+			by = nil // без начального состояния символ ни при чём
+		}
+		s.observer.OnReject(at, by)
+	}
+	return false
+}
+
+// finish сообщает наблюдателю итог проверки прочитанной целиком цепочки и возвращает его
+func (s *Session) finish() bool {
+	if !s.Accepting() {
+		return s.reject(s.current, nil)
+	}
+	if s.observer != nil {
+		s.observer.OnAccept(s.current)
+	}
+	return true
+}
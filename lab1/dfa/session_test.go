@@ -0,0 +1,74 @@
+package dfa_test
+
+import (
+	"dfa"
+	"sync"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	s := email.NewSession()
+	for _, name := range []string{"a", "@", "b", ".", "r", "u"} {
+		if s.Step(name) == nil {
+			t.Fatalf("переход по %q невозможен", name)
+		}
+	}
+	if !s.Accepting() || s.Current().String() != "s8" {
+		t.Errorf("сессия должна закончиться в заключительном состоянии s8, получено %v", s.Current())
+	}
+	if s.Step("@") != nil || s.Accepting() {
+		t.Errorf("после невозможного перехода сессия должна оказаться в тупике")
+	}
+	s.Reset()
+	if s.Current() != email.GetStartState() {
+		t.Errorf("Reset должен возвращать сессию в начальное состояние")
+	}
+
+	if email.Accepts("a@b.ru"); email.GetCurrentState() != email.GetStartState() {
+		t.Errorf("Accepts не должен изменять текущее состояние ДКА")
+	}
+}
+
+func TestNoStartState(t *testing.T) {
+	automata := dfa.NewDFA(1)
+	if automata.Accepts("") || automata.CheckChain(nil) {
+		t.Errorf("автомат без начального состояния не принимает цепочек")
+	}
+}
+
+// TestConcurrentAccepts проверяет совместное использование одного ДКА из многих горутин.
+// Гонки данных выявляются при запуске с флагом -race
+func TestConcurrentAccepts(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	want := make([]bool, len(emailSamples))
+	for i, s := range emailSamples {
+		want[i] = email.Accepts(s)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 64)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			session := email.NewSession()
+			for n := 0; n < 200; n++ {
+				i := (g + n) % len(emailSamples)
+				s := emailSamples[i]
+				if email.Accepts(s) != want[i] || session.Accepts(s) != want[i] {
+					select {
+					case errs <- s:
+					default:
+					}
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for s := range errs {
+		t.Errorf("неверный результат для %q при параллельной проверке", s)
+	}
+}
@@ -0,0 +1,103 @@
+package dfa
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Stream выполняет ДКА над входом, поступающим частями, не накапливая его в памяти.
+// Вход разбирается как UTF-8; последовательность байтов одной руны может быть
+// разрезана между соседними частями
+type Stream struct {
+	m       *Matcher
+	state   int    // текущее состояние или -1, если автомат в тупике
+	pending []byte // начало руны, продолжение которой ещё не поступило
+}
+
+// NewStream компилирует ДКА и создает поток, находящийся в начальном состоянии.
+// Последующие изменения ДКА не отражаются на потоке
+func NewStream(d *DFA) *Stream {
+	return d.Compile().NewStream()
+}
+
+// NewStream создает поток по скомпилированному автомату
+func (m *Matcher) NewStream() *Stream {
+	return &Stream{m: m, state: m.Start(), pending: make([]byte, 0, utf8.UTFMax)}
+}
+
+// Reset возвращает поток в начальное состояние и отбрасывает незавершённую руну
+func (s *Stream) Reset() {
+	s.state = s.m.Start()
+	s.pending = s.pending[:0]
+}
+
+// Feed передаёт автомату очередную часть входа.
+// Возвращает false, если автомат оказался в тупике и никакое продолжение уже не будет принято
+func (s *Stream) Feed(chunk []byte) bool {
+	for len(s.pending) > 0 && len(chunk) > 0 && s.state >= 0 {
+		s.pending = append(s.pending, chunk[0])
+		chunk = chunk[1:]
+		s.drain()
+	}
+	if len(s.pending) > 0 {
+		return s.state >= 0
+	}
+
+	i := 0
+	for i < len(chunk) && s.state >= 0 {
+		if c := chunk[i]; c < utf8.RuneSelf {
+			s.state = s.m.Step(s.state, rune(c))
+			i++
+			continue
+		}
+		if !utf8.FullRune(chunk[i:]) {
+			s.pending = append(s.pending, chunk[i:]...)
+			break
+		}
+		r, size := utf8.DecodeRune(chunk[i:])
+		s.state = s.m.Step(s.state, r)
+		i += size
+	}
+	return s.state >= 0
+}
+
+// drain обрабатывает все полные руны в начале незавершённой последовательности
+func (s *Stream) drain() {
+	for len(s.pending) > 0 && utf8.FullRune(s.pending) {
+		r, size := utf8.DecodeRune(s.pending)
+		s.state = s.m.Step(s.state, r)
+		s.pending = s.pending[:copy(s.pending, s.pending[size:])]
+	}
+}
+
+// Accepting возвращает true, если поступивший на данный момент вход принадлежит языку автомата.
+// Незавершённая руна в конце входа считается некорректной последовательностью байтов,
+// как при обходе строки в цикле range
+func (s *Stream) Accepting() bool {
+	state := s.state
+	for rest := s.pending; len(rest) > 0 && state >= 0; {
+		r, size := utf8.DecodeRune(rest)
+		state = s.m.Step(state, r)
+		rest = rest[size:]
+	}
+	return s.m.IsAccepting(state)
+}
+
+// MatchReader проверяет, принадлежит ли языку ДКА вход, прочитанный из r до io.EOF.
+// Чтение прекращается досрочно, как только автомат оказывается в тупике.
+// Ошибка чтения, отличная от io.EOF, возвращается вызывающему
+func (d *DFA) MatchReader(r io.RuneReader) (bool, error) {
+	m := d.Compile()
+	state := m.Start()
+	for state >= 0 {
+		c, _, err := r.ReadRune()
+		if err == io.EOF {
+			return m.IsAccepting(state), nil
+		}
+		if err != nil {
+			return false, err
+		}
+		state = m.Step(state, c)
+	}
+	return false, nil
+}
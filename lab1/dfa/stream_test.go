@@ -0,0 +1,92 @@
+package dfa_test
+
+import (
+	"bufio"
+	"dfa"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// endingsDFA строит ДКА, распознающий русские слова на -ое, -ая, -ие
+func endingsDFA() *dfa.DFA {
+	automata := dfa.NewDFA(4)
+	for i := 'а'; i <= 'я'; i++ {
+		automata.AddLetter(string(i))
+		for _, s := range []string{"s0", "s1", "s2", "s3"} {
+			automata.SetTransition(s, "s0", string(i))
+		}
+	}
+	for _, s := range []string{"s0", "s1", "s2", "s3"} {
+		automata.SetTransition(s, "s1", "о")
+		automata.SetTransition(s, "s1", "а")
+		automata.SetTransition(s, "s2", "и")
+	}
+	automata.SetTransition("s1", "s3", "е")
+	automata.SetTransition("s1", "s3", "я")
+	automata.SetTransition("s2", "s3", "е")
+	automata.SetStartState("s0")
+	automata.SetEndState("s3")
+	return automata
+}
+
+func TestStreamSplitRunes(t *testing.T) {
+	automata := endingsDFA()
+	for _, s := range []string{"красивое", "синяя", "большие", "дом", "мое", "", "о\xd0"} {
+		want := automata.Accepts(s)
+		for cut := 0; cut <= len(s); cut++ {
+			stream := dfa.NewStream(automata)
+			stream.Feed([]byte(s[:cut]))
+			stream.Feed([]byte(s[cut:]))
+			if got := stream.Accepting(); got != want {
+				t.Errorf("%q с разрезом на %d: Accepting() = %v, ожидалось %v", s, cut, got, want)
+			}
+		}
+
+		stream := dfa.NewStream(automata)
+		for i := 0; i < len(s); i++ {
+			stream.Feed([]byte{s[i]})
+		}
+		if got := stream.Accepting(); got != want {
+			t.Errorf("%q по одному байту: Accepting() = %v, ожидалось %v", s, got, want)
+		}
+	}
+}
+
+func TestStreamDead(t *testing.T) {
+	stream := dfa.NewStream(dfa.NewEmailDFA())
+	if !stream.Feed([]byte("user@")) {
+		t.Fatalf("префикс адреса не должен приводить в тупик")
+	}
+	if stream.Accepting() {
+		t.Errorf("неполный адрес не должен приниматься")
+	}
+	if stream.Feed([]byte("@")) || stream.Feed([]byte("mail.ru")) {
+		t.Errorf("после второго @ автомат должен оказаться в тупике")
+	}
+	stream.Reset()
+	if !stream.Feed([]byte("user@mail.ru")) || !stream.Accepting() {
+		t.Errorf("после Reset поток должен принимать корректный адрес")
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) ReadRune() (rune, int, error) {
+	return 0, 0, errors.New("обрыв соединения")
+}
+
+func TestMatchReader(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	for _, s := range emailSamples {
+		got, err := email.MatchReader(bufio.NewReader(strings.NewReader(s)))
+		if err != nil || got != email.Accepts(s) {
+			t.Errorf("MatchReader(%q) = %v, %v", s, got, err)
+		}
+	}
+
+	if _, err := email.MatchReader(failingReader{}); err == nil || err == io.EOF {
+		t.Errorf("ошибка чтения должна возвращаться вызывающему")
+	}
+}
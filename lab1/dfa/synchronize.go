@@ -0,0 +1,172 @@
+package dfa
+
+// exactSyncLimit — наибольшее число состояний, при котором синхронизирующее слово
+// ищется точно обходом в ширину по подмножествам состояний
+const exactSyncLimit = 16
+
+// SynchronizingWord возвращает синхронизирующее слово: цепочку символов, переводящую
+// ДКА из любого состояния в одно и то же состояние. Для автоматов не более чем
+// из 16 состояний слово кратчайшее (обход в ширину по подмножествам), для больших
+// автоматов используется жадный алгоритм Эппштейна.
+// Возвращает ошибку ErrIncomplete, если ДКА не полон, и ErrNotSynchronizing,
+// если синхронизирующего слова не существует
+func (d *DFA) SynchronizingWord() ([]*Letter, error) {
+	t := d.table()
+	for _, row := range t.delta {
+		for _, to := range row {
+			if to < 0 {
+				return nil, ErrIncomplete
+			}
+		}
+	}
+
+	var word []int
+	var ok bool
+	if len(t.names) <= exactSyncLimit {
+		word, ok = t.syncExact()
+	} else {
+		word, ok = t.syncGreedy()
+	}
+	if !ok {
+		return nil, ErrNotSynchronizing
+	}
+
+	return t.chain(word, d), nil
+}
+
+// syncExact ищет кратчайшее синхронизирующее слово обходом в ширину по подмножествам
+// состояний, представленным битовыми масками
+func (t *table) syncExact() ([]int, bool) {
+	n := len(t.names)
+	if n <= 1 {
+		return []int{}, true
+	}
+	type step struct {
+		prev   uint32
+		letter int
+	}
+
+	all := uint32(1)<<n - 1
+	from := map[uint32]step{all: {}}
+	queue := []uint32{all}
+	for len(queue) > 0 {
+		set := queue[0]
+		queue = queue[1:]
+		if set&(set-1) == 0 {
+			var word []int
+			for set != all {
+				st := from[set]
+				word = append(word, st.letter)
+				set = st.prev
+			}
+			for i, j := 0, len(word)-1; i < j; i, j = i+1, j-1 {
+				word[i], word[j] = word[j], word[i]
+			}
+			return word, true
+		}
+		for l := range t.syms {
+			var next uint32
+			for s := 0; s < n; s++ {
+				if set&(1<<s) != 0 {
+					next |= 1 << t.delta[s][l]
+				}
+			}
+			if _, ok := from[next]; !ok {
+				from[next] = step{set, l}
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil, false
+}
+
+// syncGreedy строит синхронизирующее слово жадным алгоритмом Эппштейна: пока множество
+// текущих состояний содержит больше одного состояния, к нему применяется кратчайшее
+// слово, сливающее какую-нибудь пару его состояний
+func (t *table) syncGreedy() ([]int, bool) {
+	n := len(t.names)
+	pair := func(p, q int) int {
+		if p > q {
+			p, q = q, p
+		}
+		return p*n + q
+	}
+
+	// обратный обход в ширину по автомату пар: dist — длина кратчайшего сливающего слова,
+	// next — его первый символ
+	inv := make([][][]int, len(t.syms))
+	for l := range t.syms {
+		inv[l] = make([][]int, n)
+		for s := 0; s < n; s++ {
+			to := t.delta[s][l]
+			inv[l][to] = append(inv[l][to], s)
+		}
+	}
+	dist := make([]int, n*n)
+	next := make([]int, n*n)
+	for i := range dist {
+		dist[i] = -1
+	}
+	var queue []int
+	for s := 0; s < n; s++ {
+		dist[pair(s, s)] = 0
+		queue = append(queue, pair(s, s))
+	}
+	for len(queue) > 0 {
+		pq := queue[0]
+		queue = queue[1:]
+		p, q := pq/n, pq%n
+		for l := range t.syms {
+			for _, pp := range inv[l][p] {
+				for _, qq := range inv[l][q] {
+					if pp == qq {
+						continue
+					}
+					if k := pair(pp, qq); dist[k] < 0 {
+						dist[k] = dist[pq] + 1
+						next[k] = l
+						queue = append(queue, k)
+					}
+				}
+			}
+		}
+	}
+	for p := 0; p < n; p++ {
+		for q := p + 1; q < n; q++ {
+			if dist[pair(p, q)] < 0 {
+				return nil, false // пару нельзя слить, значит, автомат не синхронизируем
+			}
+		}
+	}
+
+	current := make([]int, n)
+	for s := range current {
+		current[s] = s
+	}
+	word := []int{}
+	for len(current) > 1 {
+		bp, bq := current[0], current[1]
+		for i, p := range current {
+			for _, q := range current[i+1:] {
+				if dist[pair(p, q)] < dist[pair(bp, bq)] {
+					bp, bq = p, q
+				}
+			}
+		}
+		for bp != bq {
+			l := next[pair(bp, bq)]
+			word = append(word, l)
+			bp, bq = t.delta[bp][l], t.delta[bq][l]
+			seen := make(map[int]bool, len(current))
+			moved := current[:0]
+			for _, s := range current {
+				if to := t.delta[s][l]; !seen[to] {
+					seen[to] = true
+					moved = append(moved, to)
+				}
+			}
+			current = moved
+		}
+	}
+	return word, true
+}
@@ -0,0 +1,89 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// cernyDFA строит автомат Черни с n состояниями: кратчайшее синхронизирующее слово
+// имеет длину (n-1)^2
+func cernyDFA(n int) *dfa.DFA {
+	automata := dfa.NewDFA(n)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	for i := 0; i < n; i++ {
+		automata.SetTransition(fmt.Sprintf("s%d", i), fmt.Sprintf("s%d", (i+1)%n), "a")
+		automata.SetTransition(fmt.Sprintf("s%d", i), fmt.Sprintf("s%d", i), "b")
+	}
+	automata.SetTransition("s0", "s1", "b")
+	automata.SetStartState("s0")
+	return automata
+}
+
+// synchronizes проверяет, что слово переводит автомат из любого состояния в одно и то же
+func synchronizes(automata *dfa.DFA, n int, word []*dfa.Letter) bool {
+	var end *dfa.State
+	for i := 0; i < n; i++ {
+		automata.SetStartState(fmt.Sprintf("s%d", i))
+		s := automata.NewSession()
+		for _, l := range word {
+			s.Transition(l)
+		}
+		if end != nil && s.Current() != end {
+			return false
+		}
+		end = s.Current()
+	}
+	return true
+}
+
+func TestSynchronizingWordExact(t *testing.T) {
+	automata := cernyDFA(5)
+	word, err := automata.SynchronizingWord()
+	if err != nil {
+		t.Fatalf("SynchronizingWord: %v", err)
+	}
+	if len(word) != 16 {
+		t.Errorf("длина кратчайшего слова %d, ожидалось 16", len(word))
+	}
+	if !synchronizes(automata, 5, word) {
+		t.Errorf("слово %q не синхронизирует автомат", chainString(word))
+	}
+}
+
+func TestSynchronizingWordGreedy(t *testing.T) {
+	automata := cernyDFA(25)
+	word, err := automata.SynchronizingWord()
+	if err != nil {
+		t.Fatalf("SynchronizingWord: %v", err)
+	}
+	if !synchronizes(automata, 25, word) {
+		t.Errorf("слово %q не синхронизирует автомат", chainString(word))
+	}
+}
+
+func TestSynchronizingWordErrors(t *testing.T) {
+	// циклический сдвиг — перестановка, слить состояния нельзя
+	automata := cernyDFA(3)
+	automata.SetTransition("s0", "s0", "b")
+	if _, err := automata.SynchronizingWord(); !errors.Is(err, dfa.ErrNotSynchronizing) {
+		t.Errorf("ожидалась ErrNotSynchronizing, получено %v", err)
+	}
+
+	big := cernyDFA(20)
+	big.SetTransition("s0", "s0", "b")
+	if _, err := big.SynchronizingWord(); !errors.Is(err, dfa.ErrNotSynchronizing) {
+		t.Errorf("ожидалась ErrNotSynchronizing, получено %v", err)
+	}
+
+	if _, err := dfa.NewEmailDFA().SynchronizingWord(); !errors.Is(err, dfa.ErrIncomplete) {
+		t.Errorf("ожидалась ErrIncomplete, получено %v", err)
+	}
+	email := dfa.NewEmailDFA()
+	email.Complete()
+	if word, err := email.SynchronizingWord(); err != nil || len(word) != 2 {
+		t.Errorf("дополненный автомат синхронизируется двумя символами, получено %q, %v", chainString(word), err)
+	}
+}
@@ -0,0 +1,455 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// table — плотное представление ДКА: состояния и символы пронумерованы.
+// Используется алгоритмами, которым удобнее работать с индексами, а не с указателями
+type table struct {
+	names []string // имена состояний
+	term  []bool   // флаги заключительности состояний
+	syms  []symbol // символы алфавита
+	delta [][]int  // delta[s][l] — номер состояния перехода или -1, если перехода нет
+	start int      // номер начального состояния или -1, если оно не установлено
+}
+
+// symbol — символ плотного представления: буква алфавита или диапазон рун, по которым
+// есть переходы по диапазонам и нет букв. Все руны диапазона ведут из каждого состояния
+// в одно и то же состояние, поэтому диапазон обрабатывается как один символ
+type symbol struct {
+	name   string    // имя буквы
+	span   RuneRange // диапазон рун, если isSpan
+	isSpan bool      // символ является диапазоном рун
+}
+
+// String возвращает имя буквы или запись диапазона
+func (s symbol) String() string {
+	if s.isSpan {
+		return spanName(s.span)
+	}
+	return s.name
+}
+
+// size возвращает число различных цепочек из одного символа: 1 для буквы и число рун для диапазона
+func (s symbol) size() int64 {
+	if s.isSpan {
+		return int64(s.span.Hi-s.span.Lo) + 1
+	}
+	return 1
+}
+
+// example возвращает запись одной из цепочек, которые представляет символ
+func (s symbol) example() string {
+	if s.isSpan {
+		return string(s.span.Lo)
+	}
+	return s.name
+}
+
+// singleRune возвращает руну, если имя символа состоит ровно из одной руны
+func singleRune(name string) (rune, bool) {
+	r, size := utf8.DecodeRuneInString(name)
+	return r, size > 0 && size == len(name)
+}
+
+// alphabet возвращает общий алфавит плотного представления нескольких ДКА: объединение
+// их букв и элементарные диапазоны рун, на которые делят руны переходов по диапазонам
+// границы этих диапазонов. Руны, совпадающие с буквами, в диапазоны не входят, а диапазоны
+// дополнительно разрезаются так, что упорядоченные символы идут в лексикографическом
+// порядке своих записей
+func alphabet(ds ...*DFA) []symbol {
+	return alphabetCut(nil, ds...)
+}
+
+// alphabetCut строит общий алфавит, как alphabet, дополнительно разрезая диапазоны
+// на границах диапазонов cut. Так каждый символ оказывается целиком внутри или вне
+// каждого из диапазонов cut, например диапазонов с общим выходом преобразователя
+func alphabetCut(cut []RuneRange, ds ...*DFA) []symbol {
+	names := make(map[string]bool)
+	var cover Class
+	cuts := make(map[rune]bool)
+	for _, r := range cut {
+		cuts[r.Lo], cuts[r.Hi+1] = true, true
+	}
+	for _, d := range ds {
+		for l := range d.letters {
+			names[l.name] = true
+		}
+		for _, edges := range d.ranges {
+			for _, e := range edges {
+				cover = append(cover, RuneRange{e.lo, e.hi})
+				cuts[e.lo], cuts[e.hi+1] = true, true
+			}
+		}
+	}
+	letterRunes := make(map[rune]bool)
+	for name := range names {
+		r, size := utf8.DecodeRuneInString(name)
+		switch {
+		case size == 0:
+		case size == len(name):
+			letterRunes[r] = true
+			cuts[r], cuts[r+1] = true, true
+		default:
+			cuts[r+1] = true // многосимвольная буква стоит между r и r+1
+		}
+	}
+	points := make([]rune, 0, len(cuts))
+	for r := range cuts {
+		points = append(points, r)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+
+	syms := make([]symbol, 0, len(names))
+	for name := range names {
+		syms = append(syms, symbol{name: name})
+	}
+	for _, c := range NewClass(cover...) {
+		i := sort.Search(len(points), func(i int) bool { return points[i] > c.Lo })
+		for lo := c.Lo; lo <= c.Hi; i++ {
+			hi := c.Hi
+			if i < len(points) && points[i]-1 < hi {
+				hi = points[i] - 1
+			}
+			if !(lo == hi && letterRunes[lo]) {
+				syms = append(syms, symbol{span: RuneRange{lo, hi}, isSpan: true})
+			}
+			lo = hi + 1
+		}
+	}
+	sort.Slice(syms, func(i, j int) bool { return syms[i].example() < syms[j].example() })
+	return syms
+}
+
+// table строит плотное представление ДКА над его собственным алфавитом
+func (d *DFA) table() *table {
+	return d.tableOver(alphabet(d))
+}
+
+// next возвращает состояние перехода по букве или nil. Если перехода по букве нет,
+// а её имя состоит из одной руны, используется переход по диапазону, содержащему руну
+func (d *DFA) next(from *State, by *Letter) *State {
+	if to, ok := d.trans[from][by]; ok {
+		return to
+	}
+	return d.runeTarget(from, by.name)
+}
+
+// runeTarget возвращает состояние перехода по диапазону для символа, имя которого
+// состоит из одной руны, или nil
+func (d *DFA) runeTarget(from *State, name string) *State {
+	if r, ok := singleRune(name); ok {
+		return d.rangeTarget(from, r)
+	}
+	return nil
+}
+
+// sortedStates возвращает состояния ДКА в порядке имён, то есть в порядке номеров плотного представления
+func (d *DFA) sortedStates() []*State {
+	states := make([]*State, 0, len(d.states))
+	for s := range d.states {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].name < states[j].name })
+	return states
+}
+
+// tableOver строит плотное представление ДКА над заданным алфавитом.
+// Символы, которых нет в алфавите ДКА, имеют только переходы по диапазонам
+func (d *DFA) tableOver(syms []symbol) *table {
+	states := d.sortedStates()
+
+	index := make(map[*State]int, len(states))
+	for i, s := range states {
+		index[s] = i
+	}
+	byName := make(map[string]*Letter, len(d.letters))
+	for l := range d.letters {
+		byName[l.name] = l
+	}
+
+	t := &table{
+		names: make([]string, len(states)),
+		term:  make([]bool, len(states)),
+		syms:  syms,
+		delta: make([][]int, len(states)),
+		start: -1,
+	}
+	for i, s := range states {
+		t.names[i] = s.name
+		t.term[i] = s.term
+		t.delta[i] = make([]int, len(syms))
+		for j, sym := range syms {
+			var to *State
+			switch l, ok := byName[sym.name]; {
+			case sym.isSpan:
+				to = d.rangeTarget(s, sym.span.Lo)
+			case ok:
+				to = d.next(s, l)
+			default:
+				to = d.runeTarget(s, sym.name)
+			}
+			t.delta[i][j] = -1
+			if to != nil {
+				t.delta[i][j] = index[to]
+			}
+		}
+	}
+	if d.start != nil {
+		t.start = index[d.start]
+	}
+	return t
+}
+
+// lookup возвращает номер символа, которым записывается цепочка name: буквы с таким именем
+// или диапазона, содержащего единственную руну name. Второе значение равно false, если такого символа нет
+func (t *table) lookup(name string) (int, bool) {
+	for l, sym := range t.syms {
+		if !sym.isSpan && sym.name == name {
+			return l, true
+		}
+	}
+	if r, ok := singleRune(name); ok {
+		for l, sym := range t.syms {
+			if sym.isSpan && sym.span.Lo <= r && r <= sym.span.Hi {
+				return l, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// chain возвращает цепочку букв для цепочки номеров символов. Буквы берутся из алфавитов
+// автоматов ds по порядку; для диапазона и для буквы, которой нет ни в одном алфавите,
+// создаётся новая буква с записью символа: такие буквы проходят по переходам по диапазонам
+func (t *table) chain(word []int, ds ...*DFA) []*Letter {
+	chain := make([]*Letter, len(word))
+	for i, l := range word {
+		sym := t.syms[l]
+		if !sym.isSpan {
+			for _, d := range ds {
+				if chain[i] = d.FindLetterByName(sym.name); chain[i] != nil {
+					break
+				}
+			}
+		}
+		if chain[i] == nil {
+			chain[i] = NewLetter(sym.example())
+		}
+	}
+	return chain
+}
+
+// build создает новый ДКА по плотному представлению
+func (t *table) build() *DFA {
+	d, _, _ := t.buildIndexed()
+	return d
+}
+
+// buildIndexed создает новый ДКА по плотному представлению и возвращает также его состояния
+// и буквы в порядке номеров таблицы; для диапазонов вместо букв стоит nil
+func (t *table) buildIndexed() (*DFA, []*State, []*Letter) {
+	d := NewDFA(0)
+	letters := make([]*Letter, len(t.syms))
+	for i, sym := range t.syms {
+		if !sym.isSpan {
+			letters[i] = NewLetter(sym.name)
+			d.letters[letters[i]] = true
+		}
+	}
+	states := make([]*State, len(t.names))
+	for i, name := range t.names {
+		states[i] = NewState(name, t.term[i])
+		d.states[states[i]] = true
+		d.trans[states[i]] = make(map[*Letter]*State)
+	}
+	for i, row := range t.delta {
+		for j, to := range row {
+			switch {
+			case to < 0:
+			case t.syms[j].isSpan:
+				d.ranges[states[i]] = insertRange(d.ranges[states[i]], t.syms[j].span.Lo, t.syms[j].span.Hi, states[to])
+			default:
+				d.trans[states[i]][letters[j]] = states[to]
+			}
+		}
+	}
+	if t.start >= 0 {
+		d.start = states[t.start]
+		d.current = d.start
+	}
+	return d, states, letters
+}
+
+// emptyRow возвращает строку таблицы переходов без единого перехода
+func emptyRow(n int) []int {
+	row := make([]int, n)
+	for i := range row {
+		row[i] = -1
+	}
+	return row
+}
+
+// reachable возвращает множество состояний, достижимых из начального
+func (t *table) reachable() []bool {
+	seen := make([]bool, len(t.names))
+	if t.start < 0 {
+		return seen
+	}
+	seen[t.start] = true
+	queue := []int{t.start}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, to := range t.delta[s] {
+			if to >= 0 && !seen[to] {
+				seen[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+	return seen
+}
+
+// live возвращает множество состояний, из которых достижимо заключительное состояние
+func (t *table) live() []bool {
+	rev := make([][]int, len(t.names))
+	for s, row := range t.delta {
+		for _, to := range row {
+			if to >= 0 {
+				rev[to] = append(rev[to], s)
+			}
+		}
+	}
+	seen := make([]bool, len(t.names))
+	var queue []int
+	for s, term := range t.term {
+		if term {
+			seen[s] = true
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, from := range rev[s] {
+			if !seen[from] {
+				seen[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+	return seen
+}
+
+// subset возвращает плотное представление, ограниченное отмеченными состояниями.
+// Переходы в неотмеченные состояния удаляются
+func (t *table) subset(keep []bool) *table {
+	index := make([]int, len(t.names))
+	r := &table{syms: t.syms, start: -1}
+	for s := range t.names {
+		index[s] = -1
+		if keep[s] {
+			index[s] = len(r.names)
+			r.names = append(r.names, t.names[s])
+			r.term = append(r.term, t.term[s])
+		}
+	}
+	for s, row := range t.delta {
+		if !keep[s] {
+			continue
+		}
+		nrow := make([]int, len(row))
+		for j, to := range row {
+			nrow[j] = -1
+			if to >= 0 {
+				nrow[j] = index[to]
+			}
+		}
+		r.delta = append(r.delta, nrow)
+	}
+	if t.start >= 0 {
+		r.start = index[t.start]
+	}
+	return r
+}
+
+// trim удаляет недостижимые и тупиковые состояния.
+// Начальное состояние сохраняется, даже если язык автомата пуст
+func (t *table) trim() *table {
+	return t.subset(t.useful())
+}
+
+// useful возвращает множество состояний, которые сохраняет trim
+func (t *table) useful() []bool {
+	reach := t.reachable()
+	live := t.live()
+	keep := make([]bool, len(t.names))
+	for s := range keep {
+		keep[s] = reach[s] && live[s]
+	}
+	if t.start >= 0 {
+		keep[t.start] = true
+	}
+	return keep
+}
+
+// freeName возвращает имя, не совпадающее ни с одним именем состояния таблицы
+func (t *table) freeName(base string) string {
+	taken := make(map[string]bool, len(t.names))
+	for _, name := range t.names {
+		taken[name] = true
+	}
+	name := base
+	for i := 1; taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
+
+// complete возвращает полное плотное представление: недостающие переходы ведут
+// в новое незаключительное состояние-сток. Если начальное состояние не установлено,
+// началом становится сток
+func (t *table) complete() *table {
+	full := t.start >= 0
+	for _, row := range t.delta {
+		for _, to := range row {
+			if to < 0 {
+				full = false
+			}
+		}
+	}
+	if full {
+		return t
+	}
+
+	sink := len(t.names)
+	r := &table{
+		names: append(append([]string(nil), t.names...), t.freeName("sink")),
+		term:  append(append([]bool(nil), t.term...), false),
+		syms:  t.syms,
+		start: t.start,
+	}
+	for _, row := range t.delta {
+		nrow := make([]int, len(row))
+		for j, to := range row {
+			nrow[j] = to
+			if to < 0 {
+				nrow[j] = sink
+			}
+		}
+		r.delta = append(r.delta, nrow)
+	}
+	srow := make([]int, len(t.syms))
+	for j := range srow {
+		srow[j] = sink
+	}
+	r.delta = append(r.delta, srow)
+	if r.start < 0 {
+		r.start = sink
+	}
+	return r
+}
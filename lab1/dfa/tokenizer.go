@@ -0,0 +1,111 @@
+package dfa
+
+import "unicode/utf8"
+
+// Tokenizer разбивает строку на символы алфавита ДКА по правилу наибольшего совпадения:
+// в каждой позиции выбирается символ с самым длинным именем, которым начинается остаток строки.
+// Имена символов хранятся в префиксном дереве, поэтому поиск символа не зависит от размера алфавита.
+// Руна, с которой не начинается ни одно имя, но по которой в ДКА есть переход по диапазону,
+// становится отдельным символом с именем из этой руны.
+// Разбиение жадное и не возвращается назад: при алфавите {a, ab, bc} строка "abc"
+// не разбивается, хотя разбиение a, bc существует.
+// Tokenizer не изменяется после создания и может использоваться из нескольких горутин одновременно,
+// пока никто не изменяет ДКА; последующие изменения алфавита на Tokenizer не отражаются
+type Tokenizer struct {
+	dfa   *DFA      // автомат, символы которого распознаются
+	root  *trieNode // корень префиксного дерева имён символов
+	cover Class     // руны, по которым в ДКА есть переходы по диапазонам
+}
+
+// trieNode — узел префиксного дерева имён символов, ветвящегося по байтам
+type trieNode struct {
+	next   map[byte]*trieNode // дочерние узлы
+	letter *Letter            // символ, имя которого заканчивается в узле, или nil
+}
+
+// NewTokenizer строит Tokenizer по текущему алфавиту ДКА
+func (d *DFA) NewTokenizer() *Tokenizer {
+	t := &Tokenizer{dfa: d, root: &trieNode{}}
+	for l := range d.letters {
+		node := t.root
+		for i := 0; i < len(l.name); i++ {
+			child, ok := node.next[l.name[i]]
+			if !ok {
+				child = &trieNode{}
+				if node.next == nil {
+					node.next = make(map[byte]*trieNode)
+				}
+				node.next[l.name[i]] = child
+			}
+			node = child
+		}
+		if l.name != "" {
+			node.letter = l // символ с пустым именем не может быть выделен из строки
+		}
+	}
+	var cover Class
+	for _, edges := range d.ranges {
+		for _, e := range edges {
+			cover = append(cover, RuneRange{e.lo, e.hi})
+		}
+	}
+	t.cover = NewClass(cover...)
+	return t
+}
+
+// longest возвращает символ, которым начинается строка, и длину его имени в байтах.
+// Если ни одно имя не подходит, возвращает nil и 0
+func (t *Tokenizer) longest(s string) (*Letter, int) {
+	var best *Letter
+	size := 0
+	node := t.root
+	for i := 0; i < len(s) && node != nil; i++ {
+		node = node.next[s[i]]
+		if node != nil && node.letter != nil {
+			best, size = node.letter, i+1
+		}
+	}
+	if best == nil {
+		if r, n := utf8.DecodeRuneInString(s); n > 0 && t.cover.Contains(r) {
+			return NewLetter(s[:n]), n
+		}
+	}
+	return best, size
+}
+
+// Tokenize разбивает строку на символы алфавита ДКА.
+// Возвращает ошибку TokenError с ErrNoToken, если в какой-то позиции не начинается ни один символ
+func (t *Tokenizer) Tokenize(s string) ([]*Letter, error) {
+	var chain []*Letter
+	for i := 0; i < len(s); {
+		l, n := t.longest(s[i:])
+		if l == nil {
+			return chain, &TokenError{Offset: i, Err: ErrNoToken}
+		}
+		chain = append(chain, l)
+		i += n
+	}
+	return chain, nil
+}
+
+// Accepts разбивает строку на символы алфавита и проверяет полученную цепочку
+// на принадлежность языку ДКА. Строка, которую нельзя разбить, языку не принадлежит
+func (t *Tokenizer) Accepts(s string) bool {
+	session := t.dfa.NewSession()
+	for i := 0; i < len(s); {
+		l, n := t.longest(s[i:])
+		if l == nil || session.Transition(l) == nil {
+			return false
+		}
+		i += n
+	}
+	return session.Accepting()
+}
+
+// AcceptsTokens проверяет строку на принадлежность языку ДКА, разбивая её на символы алфавита
+// по правилу наибольшего совпадения, а не по рунам, как Accepts. Так распознаются символы
+// с многосимвольными именами, например ключевые слова или "\r\n".
+// Для многократных проверок выгоднее один раз построить NewTokenizer
+func (d *DFA) AcceptsTokens(s string) bool {
+	return d.NewTokenizer().Accepts(s)
+}
@@ -0,0 +1,89 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"testing"
+)
+
+// assignDFA строит автомат над алфавитом лексем: присваивания вида x := 1; и x == 1; через пробел.
+// Имена переменных — латинские строчные буквы, заданные диапазоном
+func assignDFA() *dfa.DFA {
+	automata := dfa.NewDFA(6)
+	for _, l := range []string{":=", "==", "=", ";", " ", "\r\n", "1"} {
+		automata.AddLetter(l)
+	}
+	automata.SetRangeTransition("s0", "s1", 'a', 'z')
+	automata.SetTransition("s1", "s2", " ")
+	automata.SetTransition("s2", "s3", ":=")
+	automata.SetTransition("s2", "s3", "==")
+	automata.SetTransition("s3", "s4", " ")
+	automata.SetTransition("s4", "s5", "1")
+	automata.SetTransition("s5", "s0", ";")
+	automata.SetTransition("s0", "s0", "\r\n")
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+	return automata
+}
+
+func TestTokenize(t *testing.T) {
+	tok := assignDFA().NewTokenizer()
+	chain, err := tok.Tokenize("x := 1;\r\ny == 1;")
+	if err != nil {
+		t.Fatalf("Tokenize: %v", err)
+	}
+	want := []string{"x", " ", ":=", " ", "1", ";", "\r\n", "y", " ", "==", " ", "1", ";"}
+	if len(chain) != len(want) {
+		t.Fatalf("Tokenize: %d символов, ожидалось %d", len(chain), len(want))
+	}
+	for i, l := range chain {
+		if l.String() != want[i] {
+			t.Errorf("символ %d: %q, ожидался %q", i, l.String(), want[i])
+		}
+	}
+
+	_, err = tok.Tokenize("x :- 1;")
+	var te *dfa.TokenError
+	if !errors.As(err, &te) || te.Offset != 2 || !errors.Is(err, dfa.ErrNoToken) {
+		t.Errorf("Tokenize: ожидалась TokenError со смещением 2, получено %v", err)
+	}
+}
+
+func TestAcceptsTokens(t *testing.T) {
+	automata := assignDFA()
+	tok := automata.NewTokenizer()
+	for s, ok := range map[string]bool{
+		"":                       true,
+		"x := 1;":                true,
+		"x := 1;\r\ny == 1;\r\n": true,
+		"x = 1;":                 false, // = — отдельный символ, а не часть :=
+		"x ::= 1;":               false,
+		"x := 1":                 false,
+		"\r":                     false,
+	} {
+		if tok.Accepts(s) != ok || automata.AcceptsTokens(s) != ok {
+			t.Errorf("AcceptsTokens(%q) = %v", s, !ok)
+		}
+	}
+	// по рунам многосимвольные символы не распознаются
+	if automata.Accepts("x := 1;") {
+		t.Errorf("Accepts не должен выделять символ :=")
+	}
+}
+
+func TestTokenizeLongestMatch(t *testing.T) {
+	automata := dfa.NewDFA(1)
+	for _, l := range []string{"a", "ab", "bc", "abc"} {
+		automata.AddLetter(l)
+	}
+	tok := automata.NewTokenizer()
+	chain, err := tok.Tokenize("abcab")
+	if err != nil || len(chain) != 2 || chain[0].String() != "abc" || chain[1].String() != "ab" {
+		t.Errorf("Tokenize(abcab) = %v, %v", chain, err)
+	}
+	// разбиение жадное: после ab не начинается ни один символ, хотя a, bc подошло бы
+	automata.RemoveLetter(automata.FindLetterByName("abc"))
+	if _, err := automata.NewTokenizer().Tokenize("abc"); !errors.Is(err, dfa.ErrNoToken) {
+		t.Errorf("Tokenize(abc) без символа abc: %v", err)
+	}
+}
@@ -0,0 +1,438 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Emit — выход перехода автомата Мили: строка Text, за которой, если Echo истинно,
+// следует прочитанный символ, каждая руна которого сдвинута на Shift.
+// Например, Emit{Echo: true, Shift: 'a' - 'A'} переводит заглавные латинские буквы в строчные,
+// а Emit{Echo: true} копирует символ без изменений
+type Emit struct {
+	Text  string // постоянная часть выхода
+	Echo  bool   // дописывать прочитанный символ
+	Shift rune   // сдвиг рун прочитанного символа
+}
+
+// apply возвращает выход перехода по символу in
+func (e Emit) apply(in string) string {
+	if !e.Echo {
+		return e.Text
+	}
+	var b strings.Builder
+	b.WriteString(e.Text)
+	for _, r := range in {
+		b.WriteRune(r + e.Shift)
+	}
+	return b.String()
+}
+
+// key возвращает запись выхода, по которой сравниваются переходы при минимизации
+func (e Emit) key() string {
+	return fmt.Sprintf("%q/%t/%d", e.Text, e.Echo, e.Shift)
+}
+
+// emitRange — выход переходов по диапазону рун
+type emitRange struct {
+	lo, hi rune
+	emit   Emit
+}
+
+// setEmits назначает выход e всем рунам класса в упорядоченном списке выходов по диапазонам
+func setEmits(list []emitRange, c Class, e Emit) []emitRange {
+	for _, r := range c {
+		var out []emitRange
+		for _, x := range list {
+			if x.hi < r.Lo || x.lo > r.Hi {
+				out = append(out, x)
+				continue
+			}
+			if x.lo < r.Lo {
+				out = append(out, emitRange{x.lo, r.Lo - 1, x.emit})
+			}
+			if x.hi > r.Hi {
+				out = append(out, emitRange{r.Hi + 1, x.hi, x.emit})
+			}
+		}
+		list = append(out, emitRange{r.Lo, r.Hi, e})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].lo < list[j].lo })
+	return list
+}
+
+// findEmit возвращает выход для руны по упорядоченному списку выходов по диапазонам
+func findEmit(list []emitRange, r rune) Emit {
+	i := sort.Search(len(list), func(i int) bool { return list[i].hi >= r })
+	if i < len(list) && list[i].lo <= r {
+		return list[i].emit
+	}
+	return Emit{}
+}
+
+// Moore — автомат Мура: ДКА, каждое состояние которого выдаёт строку.
+// Выход преобразования — выход начального состояния, за которым следуют выходы
+// состояний, в которые автомат переходит по каждой руне входной строки
+type Moore struct {
+	dfa    *DFA              // автомат переходов
+	output map[*State]string // выходы состояний
+}
+
+// NewMoore создает автомат Мура над ДКА d с пустыми выходами состояний.
+// ДКА не копируется: его последующие изменения отражаются на автомате Мура
+func NewMoore(d *DFA) *Moore {
+	return &Moore{dfa: d, output: make(map[*State]string)}
+}
+
+// DFA возвращает автомат переходов
+func (m *Moore) DFA() *DFA {
+	return m.dfa
+}
+
+// SetOutput задаёт выход состояния с заданным именем
+// Возвращает ошибку ErrUnknownState, если состояние не принадлежит ДКА
+func (m *Moore) SetOutput(stateName, out string) error {
+	s := m.dfa.FindStateByName(stateName)
+	if s == nil {
+		return &StateError{Op: "SetOutput", Name: stateName, Err: ErrUnknownState}
+	}
+	m.output[s] = out
+	return nil
+}
+
+// Output возвращает выход состояния с заданным именем или пустую строку
+func (m *Moore) Output(stateName string) string {
+	return m.output[m.dfa.FindStateByName(stateName)]
+}
+
+// Translate преобразует входную строку, читая её по одной руне, как Accepts.
+// Возвращает ошибку ErrNoStartState, TransitionError с ErrUnknownTransition, если по руне нет
+// перехода, или StateError с ErrNotAccepted, если строка закончилась в незаключительном состоянии.
+// При ошибке возвращается выход, накопленный до её обнаружения
+func (m *Moore) Translate(input string) (string, error) {
+	d := m.dfa
+	if d.start == nil {
+		return "", ErrNoStartState
+	}
+	var b strings.Builder
+	s := d.start
+	b.WriteString(m.output[s])
+	for _, r := range input {
+		var to *State
+		if by := d.FindLetterByName(string(r)); by != nil {
+			to = d.next(s, by)
+		} else {
+			to = d.runeTarget(s, string(r))
+		}
+		if to == nil {
+			return b.String(), &TransitionError{Op: "Translate", From: s.name, Letter: string(r), Err: ErrUnknownTransition}
+		}
+		s = to
+		b.WriteString(m.output[s])
+	}
+	if !s.term {
+		return b.String(), &StateError{Op: "Translate", Name: s.name, Err: ErrNotAccepted}
+	}
+	return b.String(), nil
+}
+
+// outputs возвращает выходы состояний в порядке номеров плотного представления
+func (m *Moore) outputs() []string {
+	states := m.dfa.sortedStates()
+	out := make([]string, len(states))
+	for i, s := range states {
+		out[i] = m.output[s]
+	}
+	return out
+}
+
+// ToMealy возвращает эквивалентный автомат Мили: каждый переход выдаёт выход состояния,
+// в которое он ведёт, а выход начального состояния становится начальным выходом
+func (m *Moore) ToMealy() *Mealy {
+	t := m.dfa.table()
+	out := m.outputs()
+	d, states, letters := t.buildIndexed()
+	r := NewMealy(d)
+	if t.start >= 0 {
+		r.initial = out[t.start]
+	}
+	for s, row := range t.delta {
+		for l, to := range row {
+			if to >= 0 {
+				r.setEmit(states[s], letters[l], t.syms[l], Emit{Text: out[to]})
+			}
+		}
+	}
+	return r
+}
+
+// Minimize возвращает автомат Мура с минимальным числом состояний, выполняющий то же преобразование.
+// Недостижимые и тупиковые состояния удаляются, состояния с одинаковыми выходами,
+// заключительностью и переходами в эквивалентные состояния объединяются
+func (m *Moore) Minimize() *Moore {
+	t := m.dfa.table()
+	keep := t.useful()
+	var out []string
+	for s, o := range m.outputs() {
+		if keep[s] {
+			out = append(out, o)
+		}
+	}
+	t = t.subset(keep)
+
+	q, rep := t.minimizeBy(func(s int) string {
+		return strconv.FormatBool(t.term[s]) + strconv.Quote(out[s])
+	}, nil)
+	d, states, _ := q.buildIndexed()
+	r := NewMoore(d)
+	for c, s := range states {
+		r.output[s] = out[rep[c]]
+	}
+	return r
+}
+
+// Mealy — автомат Мили: ДКА, каждый переход которого выдаёт строку Emit.
+// Выход преобразования — начальный выход, за которым следуют выходы переходов
+// по каждой руне входной строки
+type Mealy struct {
+	dfa       *DFA                        // автомат переходов
+	initial   string                      // выход перед чтением входной строки
+	letterOut map[*State]map[*Letter]Emit // выходы переходов по символам
+	rangeOut  map[*State][]emitRange      // выходы переходов по диапазонам рун
+}
+
+// NewMealy создает автомат Мили над ДКА d с пустыми выходами переходов.
+// ДКА не копируется: его последующие изменения отражаются на автомате Мили
+func NewMealy(d *DFA) *Mealy {
+	return &Mealy{
+		dfa:       d,
+		letterOut: make(map[*State]map[*Letter]Emit),
+		rangeOut:  make(map[*State][]emitRange),
+	}
+}
+
+// DFA возвращает автомат переходов
+func (m *Mealy) DFA() *DFA {
+	return m.dfa
+}
+
+// SetInitialOutput задаёт выход, с которого начинается любое преобразование
+func (m *Mealy) SetInitialOutput(out string) {
+	m.initial = out
+}
+
+// SetOutput задаёт выход перехода из заданного состояния по символу алфавита
+// Возвращает ошибку ErrUnknownState или ErrUnknownLetter, если какой-то из параметров не принадлежит ДКА
+func (m *Mealy) SetOutput(fromName, letterBy string, e Emit) error {
+	from := m.dfa.FindStateByName(fromName)
+	if from == nil {
+		return &StateError{Op: "SetOutput", Name: fromName, Err: ErrUnknownState}
+	}
+	by := m.dfa.FindLetterByName(letterBy)
+	if by == nil {
+		return &LetterError{Op: "SetOutput", Name: letterBy, Err: ErrUnknownLetter}
+	}
+	m.setEmit(from, by, symbol{}, e)
+	return nil
+}
+
+// SetClassOutput задаёт выход переходов из заданного состояния по рунам класса,
+// для которых переход выполняется по диапазону
+// Возвращает ошибку ErrUnknownState, если состояние не принадлежит ДКА
+func (m *Mealy) SetClassOutput(fromName string, c Class, e Emit) error {
+	from := m.dfa.FindStateByName(fromName)
+	if from == nil {
+		return &StateError{Op: "SetClassOutput", Name: fromName, Err: ErrUnknownState}
+	}
+	m.rangeOut[from] = setEmits(m.rangeOut[from], NewClass(c...), e)
+	return nil
+}
+
+// setEmit задаёт выход перехода по букве by или, если by равен nil, по диапазону sym
+func (m *Mealy) setEmit(from *State, by *Letter, sym symbol, e Emit) {
+	if by == nil {
+		m.rangeOut[from] = setEmits(m.rangeOut[from], Class{sym.span}, e)
+		return
+	}
+	if m.letterOut[from] == nil {
+		m.letterOut[from] = make(map[*Letter]Emit)
+	}
+	m.letterOut[from][by] = e
+}
+
+// step выполняет переход по руне и возвращает новое состояние и выход перехода.
+// Если перехода нет, возвращает nil
+func (m *Mealy) step(from *State, r rune) (*State, string) {
+	name := string(r)
+	if by := m.dfa.FindLetterByName(name); by != nil {
+		if to, ok := m.dfa.trans[from][by]; ok {
+			return to, m.letterOut[from][by].apply(name)
+		}
+	}
+	if to := m.dfa.rangeTarget(from, r); to != nil {
+		return to, findEmit(m.rangeOut[from], r).apply(name)
+	}
+	return nil, ""
+}
+
+// Translate преобразует входную строку, читая её по одной руне, как Accepts.
+// Возвращает ошибку ErrNoStartState, TransitionError с ErrUnknownTransition, если по руне нет
+// перехода, или StateError с ErrNotAccepted, если строка закончилась в незаключительном состоянии.
+// При ошибке возвращается выход, накопленный до её обнаружения
+func (m *Mealy) Translate(input string) (string, error) {
+	if m.dfa.start == nil {
+		return "", ErrNoStartState
+	}
+	var b strings.Builder
+	b.WriteString(m.initial)
+	s := m.dfa.start
+	for _, r := range input {
+		to, out := m.step(s, r)
+		if to == nil {
+			return b.String(), &TransitionError{Op: "Translate", From: s.name, Letter: string(r), Err: ErrUnknownTransition}
+		}
+		b.WriteString(out)
+		s = to
+	}
+	if !s.term {
+		return b.String(), &StateError{Op: "Translate", Name: s.name, Err: ErrNotAccepted}
+	}
+	return b.String(), nil
+}
+
+// table строит плотное представление автомата переходов и выходы его переходов:
+// emits[s][l] — выход перехода из s по символу l. Диапазоны символов разрезаются
+// на границах диапазонов выходов, поэтому выход каждого символа однозначен
+func (m *Mealy) table() (*table, [][]Emit) {
+	var cut []RuneRange
+	for _, list := range m.rangeOut {
+		for _, x := range list {
+			cut = append(cut, RuneRange{x.lo, x.hi})
+		}
+	}
+	t := m.dfa.tableOver(alphabetCut(cut, m.dfa))
+	byName := make(map[string]*Letter, len(m.dfa.letters))
+	for l := range m.dfa.letters {
+		byName[l.name] = l
+	}
+	emits := make([][]Emit, len(t.names))
+	for i, s := range m.dfa.sortedStates() {
+		emits[i] = make([]Emit, len(t.syms))
+		for l, sym := range t.syms {
+			if t.delta[i][l] < 0 {
+				continue
+			}
+			if by, ok := byName[sym.name]; ok && !sym.isSpan {
+				if _, ok := m.dfa.trans[s][by]; ok {
+					emits[i][l] = m.letterOut[s][by]
+					continue
+				}
+			}
+			r := sym.span.Lo
+			if !sym.isSpan {
+				r, _ = singleRune(sym.name)
+			}
+			emits[i][l] = findEmit(m.rangeOut[s], r)
+		}
+	}
+	return t, emits
+}
+
+// ToMoore возвращает эквивалентный автомат Мура. Состояние автомата Мили расщепляется
+// по числу различных выходов входящих в него переходов; части получают имена вида s1/2.
+// Возвращает ошибку ErrEchoOutput, если выход какого-то перехода зависит от прочитанной руны
+func (m *Mealy) ToMoore() (*Moore, error) {
+	t, emits := m.table()
+	for _, row := range emits {
+		for _, e := range row {
+			if e.Echo {
+				return nil, ErrEchoOutput
+			}
+		}
+	}
+	if t.start < 0 {
+		return NewMoore(t.build()), nil
+	}
+
+	// состояния автомата Мура — пары из состояния и выхода входящего перехода
+	type pair struct {
+		s   int
+		out string
+	}
+	r := &table{syms: t.syms, start: 0}
+	index := map[pair]int{}
+	var pairs []pair
+	visit := func(p pair) int {
+		if i, ok := index[p]; ok {
+			return i
+		}
+		index[p] = len(pairs)
+		pairs = append(pairs, p)
+		r.term = append(r.term, t.term[p.s])
+		r.delta = append(r.delta, nil)
+		return len(pairs) - 1
+	}
+	visit(pair{t.start, m.initial})
+	for i := 0; i < len(pairs); i++ {
+		row := emptyRow(len(t.syms))
+		for l, to := range t.delta[pairs[i].s] {
+			if to >= 0 {
+				row[l] = visit(pair{to, emits[pairs[i].s][l].Text})
+			}
+		}
+		r.delta[i] = row
+	}
+
+	parts := make([]int, len(t.names))
+	for _, p := range pairs {
+		parts[p.s]++
+	}
+	seen := make([]int, len(t.names))
+	for _, p := range pairs {
+		name := t.names[p.s]
+		if parts[p.s] > 1 {
+			seen[p.s]++
+			name += "/" + strconv.Itoa(seen[p.s])
+		}
+		r.names = append(r.names, name)
+	}
+	d, states, _ := r.buildIndexed()
+	moore := NewMoore(d)
+	for i, p := range pairs {
+		moore.output[states[i]] = p.out
+	}
+	return moore, nil
+}
+
+// Minimize возвращает автомат Мили с минимальным числом состояний, выполняющий то же преобразование.
+// Недостижимые и тупиковые состояния удаляются, состояния с одинаковой заключительностью,
+// переходы которых по каждому символу дают одинаковый выход и ведут в эквивалентные состояния,
+// объединяются
+func (m *Mealy) Minimize() *Mealy {
+	t, emits := m.table()
+	keep := t.useful()
+	var kept [][]Emit
+	for s, row := range emits {
+		if keep[s] {
+			kept = append(kept, row)
+		}
+	}
+	t = t.subset(keep)
+
+	q, rep := t.minimizeBy(func(s int) string {
+		return strconv.FormatBool(t.term[s])
+	}, func(s, l int) string { return kept[s][l].key() })
+	d, states, letters := q.buildIndexed()
+	r := NewMealy(d)
+	r.initial = m.initial
+	for c, row := range q.delta {
+		for l, to := range row {
+			if to >= 0 {
+				r.setEmit(states[c], letters[l], q.syms[l], kept[rep[c]][l])
+			}
+		}
+	}
+	return r
+}
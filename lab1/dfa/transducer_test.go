@@ -0,0 +1,188 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"testing"
+)
+
+// parityMoore строит автомат Мура, выдающий после каждого символа чётность числа прочитанных "a".
+// Состояния s2 и s3 повторяют s0 и s1, поэтому минимальный автомат имеет два состояния
+func parityMoore() *dfa.Moore {
+	automata := dfa.NewDFA(4)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	for _, tr := range [][3]string{
+		{"s0", "s1", "a"}, {"s0", "s0", "b"},
+		{"s1", "s2", "a"}, {"s1", "s3", "b"},
+		{"s2", "s3", "a"}, {"s2", "s2", "b"},
+		{"s3", "s0", "a"}, {"s3", "s1", "b"},
+	} {
+		automata.SetTransition(tr[0], tr[1], tr[2])
+	}
+	automata.SetStartState("s0")
+	for _, s := range []string{"s0", "s1", "s2", "s3"} {
+		automata.SetEndState(s)
+	}
+	m := dfa.NewMoore(automata)
+	m.SetOutput("s0", "0")
+	m.SetOutput("s1", "1")
+	m.SetOutput("s2", "0")
+	m.SetOutput("s3", "1")
+	return m
+}
+
+// lowerMealy строит автомат Мили, переводящий латинские буквы в строчные и заменяющий цифры на "#".
+// Состояние s1 повторяет s0
+func lowerMealy() *dfa.Mealy {
+	automata := dfa.NewDFA(2)
+	upper := dfa.NewClass(dfa.RuneRange{Lo: 'A', Hi: 'Z'})
+	lower := dfa.NewClass(dfa.RuneRange{Lo: 'a', Hi: 'z'})
+	m := dfa.NewMealy(automata)
+	for _, tr := range [][2]string{{"s0", "s1"}, {"s1", "s0"}} {
+		automata.SetClassTransition(tr[0], tr[1], dfa.ClassLatin)
+		automata.SetClassTransition(tr[0], tr[1], dfa.ClassDigit)
+		m.SetClassOutput(tr[0], upper, dfa.Emit{Echo: true, Shift: 'a' - 'A'})
+		m.SetClassOutput(tr[0], lower, dfa.Emit{Echo: true})
+		m.SetClassOutput(tr[0], dfa.ClassDigit, dfa.Emit{Text: "#"})
+	}
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+	automata.SetEndState("s1")
+	return m
+}
+
+func TestMooreTranslate(t *testing.T) {
+	m := parityMoore()
+	for in, want := range map[string]string{"": "0", "aab": "0100", "bab": "0011", "aaaa": "01010"} {
+		if got, err := m.Translate(in); err != nil || got != want {
+			t.Errorf("Translate(%q) = %q, %v; ожидалось %q", in, got, err, want)
+		}
+	}
+	if got, err := m.Translate("ac"); !errors.Is(err, dfa.ErrUnknownTransition) || got != "01" {
+		t.Errorf("Translate по руне вне алфавита: %q, %v", got, err)
+	}
+	if err := m.SetOutput("s9", "x"); !errors.Is(err, dfa.ErrUnknownState) {
+		t.Errorf("SetOutput несуществующего состояния: %v", err)
+	}
+	if m.Output("s1") != "1" {
+		t.Errorf("Output(s1) = %q", m.Output("s1"))
+	}
+}
+
+func TestMealyTranslate(t *testing.T) {
+	m := lowerMealy()
+	m.SetInitialOutput(">")
+	if got, err := m.Translate("HeLLo42"); err != nil || got != ">hello##" {
+		t.Errorf("Translate = %q, %v", got, err)
+	}
+	if got, err := m.Translate("ab-c"); !errors.Is(err, dfa.ErrUnknownTransition) || got != ">ab" {
+		t.Errorf("Translate по руне без перехода: %q, %v", got, err)
+	}
+
+	// переход по символу алфавита выдаёт свой выход, а не выход диапазона
+	m.DFA().AddLetter("Q")
+	m.DFA().SetTransition("s0", "s1", "Q")
+	if err := m.SetOutput("s0", "Q", dfa.Emit{Text: "kw"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.Translate("QQ"); got != ">kwq" {
+		t.Errorf("Translate(QQ) = %q", got)
+	}
+	if err := m.SetOutput("s0", "W", dfa.Emit{}); !errors.Is(err, dfa.ErrUnknownLetter) {
+		t.Errorf("SetOutput по неизвестному символу: %v", err)
+	}
+
+	// строка должна заканчиваться в заключительном состоянии
+	automata := dfa.NewDFA(2)
+	automata.SetRangeTransition("s0", "s1", 'a', 'z')
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	if _, err := dfa.NewMealy(automata).Translate(""); !errors.Is(err, dfa.ErrNotAccepted) {
+		t.Errorf("Translate пустой строки: %v", err)
+	}
+	if _, err := dfa.NewMealy(dfa.NewDFA(1)).Translate("a"); !errors.Is(err, dfa.ErrNoStartState) {
+		t.Errorf("Translate без начального состояния: %v", err)
+	}
+}
+
+func TestTransducerConversions(t *testing.T) {
+	moore := parityMoore()
+	mealy := moore.ToMealy()
+	back, err := mealy.ToMoore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"", "a", "ab", "bba", "abab", "aaabbb"} {
+		want, _ := moore.Translate(in)
+		if got, err := mealy.Translate(in); err != nil || got != want {
+			t.Errorf("ToMealy: Translate(%q) = %q, %v; ожидалось %q", in, got, err, want)
+		}
+		if got, err := back.Translate(in); err != nil || got != want {
+			t.Errorf("ToMoore: Translate(%q) = %q, %v; ожидалось %q", in, got, err, want)
+		}
+	}
+
+	// состояние, в которое входят переходы с разными выходами, расщепляется
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s0", "s1", "b")
+	automata.SetTransition("s1", "s0", "a")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+	split := dfa.NewMealy(automata)
+	split.SetOutput("s0", "a", dfa.Emit{Text: "x"})
+	split.SetOutput("s0", "b", dfa.Emit{Text: "y"})
+	result, err := split.ToMoore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DFA().FindStateByName("s1/1") == nil || result.DFA().FindStateByName("s1/2") == nil {
+		t.Errorf("s1 должно расщепиться на s1/1 и s1/2")
+	}
+	for in, want := range map[string]string{"a": "x", "b": "y", "bab": "yy"} {
+		if got, err := result.Translate(in); err != nil || got != want {
+			t.Errorf("Translate(%q) = %q, %v; ожидалось %q", in, got, err, want)
+		}
+	}
+
+	if _, err := lowerMealy().ToMoore(); !errors.Is(err, dfa.ErrEchoOutput) {
+		t.Errorf("ToMoore с выходом, зависящим от руны: %v", err)
+	}
+}
+
+func TestTransducerMinimize(t *testing.T) {
+	moore := parityMoore()
+	min := moore.Minimize()
+	if n := min.DFA().Compile().NumStates(); n != 2 {
+		t.Errorf("Moore.Minimize: %d состояний, ожидалось 2", n)
+	}
+	for _, in := range []string{"", "a", "ab", "bba", "abab", "aaabbb"} {
+		want, _ := moore.Translate(in)
+		if got, err := min.Translate(in); err != nil || got != want {
+			t.Errorf("Moore.Minimize: Translate(%q) = %q, %v; ожидалось %q", in, got, err, want)
+		}
+	}
+
+	mealy := lowerMealy()
+	mealy.SetInitialOutput(">")
+	small := mealy.Minimize()
+	if n := small.DFA().Compile().NumStates(); n != 1 {
+		t.Errorf("Mealy.Minimize: %d состояний, ожидалось 1", n)
+	}
+	for _, in := range []string{"", "Go", "ABC123xyz", "a-b"} {
+		want, wantErr := mealy.Translate(in)
+		got, err := small.Translate(in)
+		if got != want || (err == nil) != (wantErr == nil) {
+			t.Errorf("Mealy.Minimize: Translate(%q) = %q, %v; ожидалось %q, %v", in, got, err, want, wantErr)
+		}
+	}
+
+	// переходы с разными выходами не дают объединить состояния
+	mealy.SetClassOutput("s1", dfa.ClassDigit, dfa.Emit{Text: "*"})
+	if n := mealy.Minimize().DFA().Compile().NumStates(); n != 2 {
+		t.Errorf("Mealy.Minimize: %d состояний, ожидалось 2", n)
+	}
+}
@@ -0,0 +1,208 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Reverse возвращает ДКА, распознающий обращение языка: цепочки, прочитанные справа налево.
+// Автомат с обращёнными переходами недетерминирован (начальными становятся все заключительные
+// состояния), поэтому он детерминизируется построением подмножеств
+func (d *DFA) Reverse() *DFA {
+	t := d.table().trim()
+	rev := make([][][]int, len(t.names))
+	for s := range rev {
+		rev[s] = make([][]int, len(t.syms))
+	}
+	for s, row := range t.delta {
+		for l, to := range row {
+			if to >= 0 {
+				rev[to][l] = append(rev[to][l], s)
+			}
+		}
+	}
+
+	var starts []int
+	for s, term := range t.term {
+		if term {
+			starts = append(starts, s)
+		}
+	}
+	term := make([]bool, len(t.names))
+	if t.start >= 0 {
+		term[t.start] = true
+	}
+	return determinize(t.names, t.syms, starts, term, func(s, l int) []int { return rev[s][l] }).build()
+}
+
+// PrefixClosure возвращает ДКА, распознающий все префиксы цепочек языка,
+// то есть цепочки, которые ещё можно дополнить до цепочки языка
+func (d *DFA) PrefixClosure() *DFA {
+	t := d.table().trim()
+	live := t.live()
+	for s := range t.term {
+		t.term[s] = live[s]
+	}
+	return t.build()
+}
+
+// SuffixClosure возвращает ДКА, распознающий все суффиксы цепочек языка.
+// Начальными считаются все достижимые состояния, полученный НКА детерминизируется
+func (d *DFA) SuffixClosure() *DFA {
+	t := d.table().trim()
+	var starts []int
+	if t.start >= 0 && t.live()[t.start] {
+		for s := range t.names {
+			starts = append(starts, s)
+		}
+	}
+	return determinize(t.names, t.syms, starts, t.term, func(s, l int) []int {
+		if to := t.delta[s][l]; to >= 0 {
+			return []int{to}
+		}
+		return nil
+	}).build()
+}
+
+// LeftQuotient возвращает ДКА, распознающий левое частное языка по цепочке word:
+// цепочки x, для которых word+x принадлежит языку
+func (d *DFA) LeftQuotient(word string) *DFA {
+	t := d.table()
+	s := t.start
+	for _, r := range word {
+		l, ok := t.lookup(string(r))
+		if !ok || s < 0 {
+			s = -1
+			break
+		}
+		s = t.delta[s][l]
+	}
+	if s < 0 {
+		// частное пусто: остаётся одно незаключительное начальное состояние
+		return (&table{names: []string{"s0"}, term: []bool{false}, syms: t.syms,
+			delta: [][]int{emptyRow(len(t.syms))}, start: 0}).build()
+	}
+	t.start = s
+	return t.trim().build()
+}
+
+// RightQuotient возвращает ДКА, распознающий правое частное языка по языку other:
+// цепочки x, для которых найдётся y из языка other, такая что x+y принадлежит языку.
+// Состояния и переходы ДКА сохраняются, меняются только заключительные состояния
+func (d *DFA) RightQuotient(other *DFA) *DFA {
+	syms := alphabet(d, other)
+	ta := d.tableOver(syms)
+	tb := other.tableOver(syms)
+	na, nb := len(ta.names), len(tb.names)
+
+	// обратный обход произведения от пар заключительных состояний
+	inv := func(t *table) [][][]int {
+		r := make([][][]int, len(t.names))
+		for s := range r {
+			r[s] = make([][]int, len(syms))
+		}
+		for s, row := range t.delta {
+			for l, to := range row {
+				if to >= 0 {
+					r[to][l] = append(r[to][l], s)
+				}
+			}
+		}
+		return r
+	}
+	invA, invB := inv(ta), inv(tb)
+	good := make([]bool, na*nb)
+	var queue []int
+	for p := 0; p < na; p++ {
+		for q := 0; q < nb; q++ {
+			if ta.term[p] && tb.term[q] {
+				good[p*nb+q] = true
+				queue = append(queue, p*nb+q)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		pq := queue[0]
+		queue = queue[1:]
+		p, q := pq/nb, pq%nb
+		for l := range syms {
+			for _, pp := range invA[p][l] {
+				for _, qq := range invB[q][l] {
+					if k := pp*nb + qq; !good[k] {
+						good[k] = true
+						queue = append(queue, k)
+					}
+				}
+			}
+		}
+	}
+
+	t := d.table()
+	for s := range t.term {
+		t.term[s] = tb.start >= 0 && good[s*nb+tb.start]
+	}
+	return t.build()
+}
+
+// determinize строит ДКА по НКА построением подмножеств. НКА задаётся именами состояний,
+// алфавитом, множеством начальных состояний, флагами заключительности и функцией переходов.
+// Пустое подмножество в результат не включается, поэтому полученный ДКА может быть неполным
+func determinize(names []string, syms []symbol, starts []int, term []bool, next func(s, l int) []int) *table {
+	r := &table{syms: syms, start: 0}
+	index := map[string]int{} // номер подмножества по записи номеров его состояний
+	taken := map[string]bool{}
+	var sets [][]int
+	visit := func(set []int) int {
+		sort.Ints(set)
+		key := fmt.Sprint(set)
+		if i, ok := index[key]; ok {
+			return i
+		}
+		i := len(sets)
+		index[key] = i
+		sets = append(sets, set)
+		// имена состояний могут содержать запятые и скобки, например после Minimize,
+		// поэтому разные подмножества могут получить одно имя: оно дополняется номером
+		parts := make([]string, len(set))
+		for j, s := range set {
+			parts[j] = names[s]
+		}
+		name := "{" + strings.Join(parts, ",") + "}"
+		for k := 1; taken[name]; k++ {
+			name = fmt.Sprintf("{%s}%d", strings.Join(parts, ","), k)
+		}
+		taken[name] = true
+		accept := false
+		for _, s := range set {
+			accept = accept || term[s]
+		}
+		r.names = append(r.names, name)
+		r.term = append(r.term, accept)
+		r.delta = append(r.delta, nil)
+		return i
+	}
+
+	visit(append([]int(nil), starts...))
+	for i := 0; i < len(sets); i++ {
+		row := make([]int, len(syms))
+		for l := range syms {
+			seen := map[int]bool{}
+			var to []int
+			for _, s := range sets[i] {
+				for _, t := range next(s, l) {
+					if !seen[t] {
+						seen[t] = true
+						to = append(to, t)
+					}
+				}
+			}
+			row[l] = -1
+			if len(to) > 0 {
+				row[l] = visit(to)
+			}
+		}
+		r.delta[i] = row
+	}
+	return r
+}
@@ -0,0 +1,124 @@
+package dfa_test
+
+import (
+	"dfa"
+	"math/rand"
+	"testing"
+)
+
+// reverseString возвращает строку, прочитанную справа налево
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func TestReverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	inputs := allStrings("ab", 6)
+	for k := 0; k < 100; k++ {
+		automata := randomDFA(rng, 2+rng.Intn(4), "ab")
+		rev := automata.Reverse()
+		for _, s := range inputs {
+			if rev.Accepts(s) != automata.Accepts(reverseString(s)) {
+				t.Fatalf("Reverse: расхождение на %q", s)
+			}
+		}
+	}
+}
+
+func TestPrefixClosureEmail(t *testing.T) {
+	typing := dfa.NewEmailDFA().PrefixClosure()
+	for _, s := range []string{"", "v", "vladimirov", "vladimirov_d1ma@", "vladimirov_d1ma@mail.", "a@b.co", "a@b.com"} {
+		if !typing.Accepts(s) {
+			t.Errorf("%q — допустимый префикс адреса", s)
+		}
+	}
+	for _, s := range []string{"1", "a@@", "a@b.cu", "a@b.com."} {
+		if typing.Accepts(s) {
+			t.Errorf("%q — не префикс адреса", s)
+		}
+	}
+}
+
+func TestSuffixClosure(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	suffixes := email.SuffixClosure()
+	for _, s := range []string{"", "u", "ru", "mail.ru", "@mail.ru", "a_1@mail.ru", "m"} {
+		if !suffixes.Accepts(s) {
+			t.Errorf("%q — суффикс адреса", s)
+		}
+	}
+	for _, s := range []string{"r", "co", "a@@b.ru", "a.ru."} {
+		if suffixes.Accepts(s) {
+			t.Errorf("%q — не суффикс адреса", s)
+		}
+	}
+}
+
+func TestQuotients(t *testing.T) {
+	email := dfa.NewEmailDFA()
+
+	left := email.LeftQuotient("user@mail.")
+	for s, want := range map[string]bool{"ru": true, "com": true, "r": false, "": false} {
+		if left.Accepts(s) != want {
+			t.Errorf("LeftQuotient: Accepts(%q) = %v", s, !want)
+		}
+	}
+	if !email.LeftQuotient("@").IsEmpty() {
+		t.Errorf("частное по невозможному префиксу должно быть пусто")
+	}
+
+	domain := dfa.NewDFA(4)
+	for _, r := range ".rucom" {
+		domain.AddLetter(string(r))
+	}
+	domain.SetTransition("s0", "s1", ".")
+	domain.SetTransition("s1", "s2", "r")
+	domain.SetTransition("s2", "s3", "u")
+	domain.SetStartState("s0")
+	domain.SetEndState("s3")
+
+	right := email.RightQuotient(domain)
+	for _, s := range []string{"user@mail", "a@b", "a@"} {
+		if !right.Accepts(s) {
+			t.Errorf("RightQuotient: %q + \".ru\" — адрес", s)
+		}
+	}
+	for _, s := range []string{"user@mail.ru", "user", ""} {
+		if right.Accepts(s) {
+			t.Errorf("RightQuotient: %q не должен приниматься", s)
+		}
+	}
+	if got := right.GetStartState().String(); got != "s0" {
+		t.Errorf("RightQuotient должен сохранять состояния, начальное %q", got)
+	}
+}
+
+func TestReverseCommaNames(t *testing.T) {
+	// подмножество {p,q} и подмножество из одного состояния "p,q" имеют одинаковую запись
+	automata := dfa.NewDFA(0)
+	for _, name := range []string{"s", "p", "q", "p,q"} {
+		automata.AddState(name, name == "p,q")
+	}
+	for _, l := range []string{"a", "b", "c"} {
+		automata.AddLetter(l)
+	}
+	automata.SetTransition("s", "p", "b")
+	automata.SetTransition("s", "q", "c")
+	automata.SetTransition("p", "p,q", "a")
+	automata.SetTransition("q", "p,q", "a")
+	automata.SetStartState("s")
+
+	rev := automata.Reverse()
+	for s, ok := range map[string]bool{"ab": true, "ac": true, "b": false, "aab": false, "": false} {
+		if rev.Accepts(s) != ok {
+			t.Errorf("Reverse: Accepts(%q) = %v", s, !ok)
+		}
+	}
+	if n := rev.Compile().NumStates(); n != 3 {
+		t.Errorf("Reverse: %d состояний, ожидалось 3", n)
+	}
+}
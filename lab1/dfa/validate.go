@@ -0,0 +1,119 @@
+package dfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiagnosticKind — вид замечания о структуре автомата
+type DiagnosticKind int
+
+const (
+	NoStartState     DiagnosticKind = iota // начальное состояние не установлено
+	NoTerminalStates                       // нет ни одного заключительного состояния
+	UnreachableState                       // состояние недостижимо из начального
+	DeadState                              // из состояния недостижимо ни одно заключительное
+	UnusedLetter                           // символ не используется ни в одном переходе
+	IncompleteState                        // из состояния определены переходы не по всем символам
+)
+
+// String возвращает строковое представление вида замечания
+func (k DiagnosticKind) String() string {
+	switch k {
+	case NoStartState:
+		return "нет начального состояния"
+	case NoTerminalStates:
+		return "нет заключительных состояний"
+	case UnreachableState:
+		return "недостижимое состояние"
+	case DeadState:
+		return "тупиковое состояние"
+	case UnusedLetter:
+		return "неиспользуемый символ"
+	case IncompleteState:
+		return "неполная строка переходов"
+	}
+	return fmt.Sprintf("DiagnosticKind(%d)", int(k))
+}
+
+// Diagnostic — замечание о структуре автомата
+type Diagnostic struct {
+	Kind    DiagnosticKind // вид замечания
+	State   string         // имя состояния, к которому относится замечание
+	Letter  string         // имя символа для UnusedLetter
+	Missing []string       // символы и диапазоны рун без перехода для IncompleteState
+}
+
+// String возвращает строковое представление замечания
+func (d Diagnostic) String() string {
+	switch d.Kind {
+	case UnreachableState, DeadState:
+		return fmt.Sprintf("%v: %s", d.Kind, d.State)
+	case UnusedLetter:
+		return fmt.Sprintf("%v: %q", d.Kind, d.Letter)
+	case IncompleteState:
+		return fmt.Sprintf("%v: %s, нет переходов по %s", d.Kind, d.State, strings.Join(d.Missing, " "))
+	}
+	return d.Kind.String()
+}
+
+// Validate проверяет структуру ДКА и возвращает список замечаний: отсутствие начального
+// или заключительных состояний, недостижимые и тупиковые состояния, неиспользуемые символы
+// и неполные строки таблицы переходов. Пустой список означает, что замечаний нет.
+// Замечания упорядочены по виду, а внутри вида — по именам
+func (d *DFA) Validate() []Diagnostic {
+	t := d.table()
+	var out []Diagnostic
+
+	if t.start < 0 {
+		out = append(out, Diagnostic{Kind: NoStartState})
+	}
+	anyTerm := false
+	for _, term := range t.term {
+		anyTerm = anyTerm || term
+	}
+	if !anyTerm {
+		out = append(out, Diagnostic{Kind: NoTerminalStates})
+	}
+
+	if t.start >= 0 {
+		reach := t.reachable()
+		for s, ok := range reach {
+			if !ok {
+				out = append(out, Diagnostic{Kind: UnreachableState, State: t.names[s]})
+			}
+		}
+	}
+	if anyTerm {
+		for s, ok := range t.live() {
+			if !ok {
+				out = append(out, Diagnostic{Kind: DeadState, State: t.names[s]})
+			}
+		}
+	}
+
+	used := make([]bool, len(t.syms))
+	for _, row := range t.delta {
+		for l, to := range row {
+			used[l] = used[l] || to >= 0
+		}
+	}
+	for l, ok := range used {
+		if !ok && !t.syms[l].isSpan {
+			out = append(out, Diagnostic{Kind: UnusedLetter, Letter: t.syms[l].name})
+		}
+	}
+
+	for s, row := range t.delta {
+		var missing []string
+		for l, to := range row {
+			if to < 0 {
+				missing = append(missing, t.syms[l].String())
+			}
+		}
+		if len(missing) > 0 {
+			out = append(out, Diagnostic{Kind: IncompleteState, State: t.names[s], Missing: missing})
+		}
+	}
+	return out
+}
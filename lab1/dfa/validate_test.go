@@ -0,0 +1,60 @@
+package dfa_test
+
+import (
+	"dfa"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	automata := dfa.NewDFA(4)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.AddLetter("c")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s0", "s2", "b")
+	automata.SetTransition("s1", "s1", "a")
+	automata.SetTransition("s1", "s1", "b")
+	automata.SetTransition("s2", "s2", "a")
+	automata.SetTransition("s2", "s2", "b")
+	automata.SetTransition("s3", "s1", "a")
+	automata.SetTransition("s3", "s1", "b")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+
+	got := automata.Validate()
+	want := []dfa.Diagnostic{
+		{Kind: dfa.UnreachableState, State: "s3"},
+		{Kind: dfa.DeadState, State: "s2"},
+		{Kind: dfa.UnusedLetter, Letter: "c"},
+		{Kind: dfa.IncompleteState, State: "s0", Missing: []string{"c"}},
+		{Kind: dfa.IncompleteState, State: "s1", Missing: []string{"c"}},
+		{Kind: dfa.IncompleteState, State: "s2", Missing: []string{"c"}},
+		{Kind: dfa.IncompleteState, State: "s3", Missing: []string{"c"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v\nожидалось %v", got, want)
+	}
+}
+
+func TestValidateNoStart(t *testing.T) {
+	automata := dfa.NewDFA(1)
+	if automata.IsEndState() {
+		t.Errorf("IsEndState без начального состояния должен возвращать false")
+	}
+	got := automata.Validate()
+	want := []dfa.Diagnostic{{Kind: dfa.NoStartState}, {Kind: dfa.NoTerminalStates}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, ожидалось %v", got, want)
+	}
+}
+
+func TestValidateComplete(t *testing.T) {
+	automata := maxLenDFA("ab", 2)
+	automata.Complete()
+	for _, d := range automata.Validate() {
+		if d.Kind != dfa.DeadState || d.State != "sink" {
+			t.Errorf("неожиданное замечание: %v", d)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package dfa
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCursor возвращается WordsPage, если курсор не был получен от WordsPage этого ДКА
+var ErrInvalidCursor = errors.New("dfa: некорректный курсор перечисления")
+
+// ErrInvalidLimit возвращается WordsPage, если размер страницы не положителен
+var ErrInvalidLimit = errors.New("dfa: некорректный размер страницы")
+
+// Words возвращает последовательность цепочек языка ДКА длиной не более maxLen символов
+// в порядке shortlex: сначала по длине, затем лексикографически по именам символов;
+// руны диапазонов перебираются по отдельности.
+// При maxLen < 0 длина не ограничена; для бесконечного языка последовательность
+// тогда бесконечна и должна прерываться вызывающим
+func (d *DFA) Words(maxLen int) iter.Seq[string] {
+	t := d.table().trim()
+	return func(yield func(string) bool) {
+		t.enumerate(nil, maxLen, func(word []pick) bool {
+			return yield(t.spell(word))
+		})
+	}
+}
+
+// WordsPage возвращает не более limit цепочек языка ДКА длиной не более maxLen символов,
+// следующих в порядке shortlex за курсором, и курсор для следующей страницы.
+// Пустой курсор означает начало перечисления, пустой следующий курсор — его конец.
+// Курсор непрозрачен и действителен для любого ДКА с тем же алфавитом.
+// Возвращает ошибку ErrInvalidLimit, если limit <= 0: пустая страница с тем же курсором
+// зациклила бы обход, а пустой курсор ложно сообщил бы о конце перечисления
+func (d *DFA) WordsPage(cursor string, limit, maxLen int) ([]string, string, error) {
+	if limit <= 0 {
+		return nil, "", ErrInvalidLimit
+	}
+	t := d.table().trim()
+	after, err := t.parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page []string
+	next := ""
+	t.enumerate(after, maxLen, func(word []pick) bool {
+		page = append(page, t.spell(word))
+		if len(page) == limit {
+			next = t.cursor(word)
+			return false
+		}
+		return true
+	})
+	return page, next, nil
+}
+
+// pick — выбор при перечислении цепочек: номер символа и, для диапазона, конкретная руна
+type pick struct {
+	l int  // номер символа
+	r rune // руна диапазона
+}
+
+// text возвращает запись выбранного символа
+func (t *table) text(p pick) string {
+	if t.syms[p.l].isSpan {
+		return string(p.r)
+	}
+	return t.syms[p.l].name
+}
+
+// spell склеивает записи символов цепочки
+func (t *table) spell(word []pick) string {
+	var b strings.Builder
+	for _, p := range word {
+		b.WriteString(t.text(p))
+	}
+	return b.String()
+}
+
+// cursor кодирует цепочку как курсор: длина, двоеточие и записи символов через нулевой байт
+func (t *table) cursor(word []pick) string {
+	names := make([]string, len(word))
+	for i, p := range word {
+		names[i] = t.text(p)
+	}
+	return strconv.Itoa(len(word)) + ":" + strings.Join(names, "\x00")
+}
+
+// parseCursor разбирает курсор, построенный методом cursor.
+// Для пустого курсора возвращает nil
+func (t *table) parseCursor(cursor string) ([]pick, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	head, tail, ok := strings.Cut(cursor, ":")
+	n, err := strconv.Atoi(head)
+	if !ok || err != nil || n < 0 {
+		return nil, ErrInvalidCursor
+	}
+	word := make([]pick, 0, n)
+	if n > 0 {
+		for _, name := range strings.Split(tail, "\x00") {
+			l, ok := t.lookup(name)
+			if !ok {
+				return nil, ErrInvalidCursor
+			}
+			p := pick{l: l}
+			if t.syms[l].isSpan {
+				p.r, _ = singleRune(name)
+			}
+			word = append(word, p)
+		}
+	}
+	if len(word) != n {
+		return nil, ErrInvalidCursor
+	}
+	return word, nil
+}
+
+// enumerate перечисляет в порядке shortlex цепочки языка таблицы длиной не более maxLen
+// (без ограничения при maxLen < 0), строго следующие за цепочкой after.
+// Таблица не должна содержать тупиковых состояний, кроме, возможно, начального.
+// Перечисление прекращается, когда yield возвращает false
+func (t *table) enumerate(after []pick, maxLen int, yield func([]pick) bool) {
+	if t.start < 0 || !t.live()[t.start] {
+		return // язык пуст: без ограничения длины обход петель тупикового начала не закончился бы
+	}
+
+	// can[r][s] — есть ли из s путь ровно из r символов в заключительное состояние
+	can := [][]bool{append([]bool(nil), t.term...)}
+	extend := func() {
+		prev := can[len(can)-1]
+		next := make([]bool, len(t.names))
+		for s, row := range t.delta {
+			for _, to := range row {
+				if to >= 0 && prev[to] {
+					next[s] = true
+					break
+				}
+			}
+		}
+		can = append(can, next)
+	}
+
+	// frontier — состояния, достижимые из начального ровно за L символов
+	frontier := map[int]bool{t.start: true}
+	step := func() {
+		next := make(map[int]bool)
+		for s := range frontier {
+			for _, to := range t.delta[s] {
+				if to >= 0 {
+					next[to] = true
+				}
+			}
+		}
+		frontier = next
+	}
+
+	L := 0
+	if after != nil {
+		L = len(after)
+	}
+	for i := 0; i < L; i++ {
+		step()
+		extend()
+	}
+	for ; maxLen < 0 || L <= maxLen; L++ {
+		if len(frontier) == 0 {
+			return // более длинных цепочек нет
+		}
+		if !t.walk(can, L, after, yield) {
+			return
+		}
+		after = nil
+		step()
+		extend()
+	}
+}
+
+// walk перечисляет в лексикографическом порядке цепочки языка длиной ровно L,
+// строго большие цепочки after (если она задана)
+func (t *table) walk(can [][]bool, L int, after []pick, yield func([]pick) bool) bool {
+	word := make([]pick, L)
+	var rec func(s, i int, tight bool) bool
+	rec = func(s, i int, tight bool) bool {
+		if i == L {
+			if tight {
+				return true // сама цепочка after не перечисляется
+			}
+			return yield(word)
+		}
+		lo := 0
+		if tight {
+			lo = after[i].l
+		}
+		for l := lo; l < len(t.syms); l++ {
+			to := t.delta[s][l]
+			if to < 0 || !can[L-i-1][to] {
+				continue
+			}
+			// руны диапазона перебираются по возрастанию, у буквы выбор единственный
+			first, last := rune(0), rune(0)
+			if sym := t.syms[l]; sym.isSpan {
+				first, last = sym.span.Lo, sym.span.Hi
+			}
+			if tight && l == after[i].l {
+				first = after[i].r
+			}
+			for r := first; r <= last; r++ {
+				word[i] = pick{l, r}
+				if !rec(to, i+1, tight && word[i] == after[i]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if !can[L][t.start] {
+		return true
+	}
+	return rec(t.start, 0, after != nil)
+}
@@ -0,0 +1,120 @@
+package dfa_test
+
+import (
+	"dfa"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWordsShortlex(t *testing.T) {
+	// цепочки над {a, b} чётной длины
+	automata := dfa.NewDFA(2)
+	automata.AddLetter("b")
+	automata.AddLetter("a")
+	for _, l := range []string{"a", "b"} {
+		automata.SetTransition("s0", "s1", l)
+		automata.SetTransition("s1", "s0", l)
+	}
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+
+	got := slices.Collect(automata.Words(2))
+	want := []string{"", "aa", "ab", "ba", "bb"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Words(2) = %q, ожидалось %q", got, want)
+	}
+
+	var first []string
+	for w := range automata.Words(-1) {
+		first = append(first, w)
+		if len(first) == 7 {
+			break
+		}
+	}
+	if first[5] != "aaaa" || first[6] != "aaab" {
+		t.Errorf("Words(-1) = %q", first)
+	}
+}
+
+func TestWordsEmail(t *testing.T) {
+	email := dfa.NewEmailDFA()
+	var got []string
+	for w := range email.Words(-1) {
+		got = append(got, w)
+		if len(got) == 3 {
+			break
+		}
+	}
+	want := []string{"a@.ru", "b@.ru", "c@.ru"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("первые адреса: %q, ожидалось %q", got, want)
+	}
+	for w := range email.Words(6) {
+		if !email.Accepts(w) {
+			t.Fatalf("Words вернул непринимаемую цепочку %q", w)
+		}
+	}
+}
+
+func TestWordsFinite(t *testing.T) {
+	got := slices.Collect(maxLenDFA("ab", 2).Words(-1))
+	if len(got) != 7 || got[0] != "" || got[6] != "bb" {
+		t.Errorf("Words(-1) для конечного языка = %q", got)
+	}
+	if got := slices.Collect(dfa.NewDFA(1).Words(-1)); len(got) != 0 {
+		t.Errorf("Words без начального состояния = %q", got)
+	}
+}
+
+// deadLoopDFA строит ДКА с пустым языком: тупиковое начальное состояние с петлёй по "a"
+func deadLoopDFA() *dfa.DFA {
+	automata := dfa.NewDFA(1)
+	automata.AddLetter("a")
+	automata.SetTransition("s0", "s0", "a")
+	automata.SetStartState("s0")
+	return automata
+}
+
+func TestWordsDeadStart(t *testing.T) {
+	automata := deadLoopDFA()
+	if got := slices.Collect(automata.Words(-1)); len(got) != 0 {
+		t.Errorf("Words(-1) пустого языка = %q", got)
+	}
+	if page, next, err := automata.WordsPage("", 4, -1); len(page) != 0 || next != "" || err != nil {
+		t.Errorf("WordsPage пустого языка = %q, %q, %v", page, next, err)
+	}
+}
+
+func TestWordsPage(t *testing.T) {
+	automata := maxLenDFA("ab", 3)
+	all := slices.Collect(automata.Words(-1))
+
+	var paged []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		page, next, err := automata.WordsPage(cursor, 4, -1)
+		if err != nil {
+			t.Fatalf("WordsPage: %v", err)
+		}
+		paged = append(paged, page...)
+		if next == "" {
+			break
+		}
+		if pages > len(all) {
+			t.Fatalf("перечисление по страницам не завершается")
+		}
+		cursor = next
+	}
+	if !reflect.DeepEqual(paged, all) {
+		t.Errorf("страницы %q\nожидалось %q", paged, all)
+	}
+
+	if _, _, err := automata.WordsPage("2:a\x00z", 4, -1); !errors.Is(err, dfa.ErrInvalidCursor) {
+		t.Errorf("курсор с чужим символом: получено %v", err)
+	}
+	if page, next, err := automata.WordsPage(cursor, 0, -1); !errors.Is(err, dfa.ErrInvalidLimit) || page != nil || next != "" {
+		t.Errorf("пустая страница: получено %q, %q, %v", page, next, err)
+	}
+}
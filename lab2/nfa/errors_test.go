@@ -0,0 +1,56 @@
+package nfa_test
+
+import (
+	"errors"
+	"nfa"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	automata := nfa.NewNFA(2)
+	automata.AddLetter("a")
+
+	if _, err := automata.AddStateE("s1", false); !errors.Is(err, nfa.ErrDuplicateState) {
+		t.Errorf("AddStateE: ожидалась ErrDuplicateState, получено %v", err)
+	}
+	if _, err := automata.AddLetterE("a"); !errors.Is(err, nfa.ErrDuplicateLetter) {
+		t.Errorf("AddLetterE: ожидалась ErrDuplicateLetter, получено %v", err)
+	}
+	var se *nfa.StateError
+	if err := automata.SetTransitionE("s7", "s1", "a"); !errors.As(err, &se) || se.Name != "s7" {
+		t.Errorf("SetTransitionE: ожидалась StateError для s7, получено %v", err)
+	}
+	var le *nfa.LetterError
+	if err := automata.SetTransitionE("s0", "s1", "я"); !errors.As(err, &le) || le.Name != "я" {
+		t.Errorf("SetTransitionE: ожидалась LetterError для я, получено %v", err)
+	}
+	if _, err := automata.TransitionE(automata.FindLetterByName("a")); !errors.Is(err, nfa.ErrNoStartState) {
+		t.Errorf("TransitionE без начального состояния: получено %v", err)
+	}
+}
+
+func TestRemoveTransition(t *testing.T) {
+	automata := nfa.NewNFA(3)
+	automata.AddLetter("a")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s0", "s2", "a")
+	automata.SetStartState("s0")
+	automata.SetEndState("s2")
+
+	s0, s1 := automata.FindStateByName("s0"), automata.FindStateByName("s1")
+	a := automata.FindLetterByName("a")
+	if err := automata.RemoveTransitionE(s0, s1, a); err != nil {
+		t.Fatalf("RemoveTransitionE: %v", err)
+	}
+	if !automata.Accepts("a") {
+		t.Errorf("RemoveTransitionE удалил переход s0 -a-> s2")
+	}
+	var te *nfa.TransitionError
+	if err := automata.RemoveTransitionE(s0, s1, a); !errors.As(err, &te) || te.To != "s1" {
+		t.Errorf("повторное удаление: ожидалась TransitionError, получено %v", err)
+	}
+	var se *nfa.StateError
+	if err := automata.RemoveTransitionE(s0, nil, a); !errors.As(err, &se) {
+		t.Errorf("удаление перехода в nil: ожидалась StateError, получено %v", err)
+	}
+}
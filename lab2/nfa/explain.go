@@ -0,0 +1,106 @@
+package nfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Result — результат проверки строки с объяснением причины отказа
+type Result struct {
+	Accepted    bool     // строка принадлежит языку НКА
+	NoStart     bool     // начальное состояние не установлено
+	Offset      int      // смещение в байтах, на котором остановилась обработка, или длина строки
+	RuneOffset  int      // номер руны, на которой остановилась обработка, или число рун строки
+	States      []string // имена текущих состояний в момент остановки в лексикографическом порядке
+	Symbol      string   // руна, по которой нет перехода ни из одного текущего состояния; пусто, если строка прочитана целиком
+	Expected    []string // имена символов, по которым из текущих состояний есть переходы
+	NonTerminal bool     // строка прочитана целиком, но среди текущих состояний нет заключительных
+}
+
+// String возвращает описание результата для сообщения пользователю
+func (r Result) String() string {
+	expected := "нет ни одного перехода"
+	if len(r.Expected) > 0 {
+		quoted := make([]string, len(r.Expected))
+		for i, name := range r.Expected {
+			quoted[i] = fmt.Sprintf("%q", name)
+		}
+		expected = "допустимы " + strings.Join(quoted, " ")
+	}
+	states := "{" + strings.Join(r.States, ",") + "}"
+	switch {
+	case r.Accepted:
+		return fmt.Sprintf("строка принята в состояниях %s", states)
+	case r.NoStart:
+		return "начальное состояние не установлено"
+	case r.NonTerminal:
+		return fmt.Sprintf("строка закончилась в незаключительных состояниях %s: %s", states, expected)
+	}
+	return fmt.Sprintf("смещение %d (руна %d): из состояний %s нет перехода по %q: %s",
+		r.Offset, r.RuneOffset, states, r.Symbol, expected)
+}
+
+// Explain проверяет строку, читая её по одной руне, как Accepts, и объясняет результат:
+// где остановилась обработка, в каких состояниях, на какой руне и какие символы были бы допустимы.
+// Обработка останавливается, как только множество текущих состояний становится пустым.
+// Текущее множество состояний НКА не изменяется
+func (n *NFA) Explain(s string) Result {
+	if n.start == nil {
+		return Result{NoStart: true}
+	}
+	current := []*State{n.start}
+	runes := 0
+	for i, r := range s {
+		var next []*State
+		if by := n.FindLetterByName(string(r)); by != nil {
+			seen := make(map[*State]bool)
+			for _, from := range current {
+				for _, to := range n.trans[from][by] {
+					if !seen[to] {
+						seen[to] = true
+						next = append(next, to)
+					}
+				}
+			}
+		}
+		if len(next) == 0 {
+			return Result{Offset: i, RuneOffset: runes, States: names(current), Symbol: string(r), Expected: n.expected(current)}
+		}
+		current = next
+		runes++
+	}
+	for _, st := range current {
+		if st.term {
+			return Result{Accepted: true, Offset: len(s), RuneOffset: runes, States: names(current)}
+		}
+	}
+	return Result{Offset: len(s), RuneOffset: runes, States: names(current), Expected: n.expected(current), NonTerminal: true}
+}
+
+// expected возвращает имена символов, по которым хотя бы из одного состояния есть переход,
+// в лексикографическом порядке
+func (n *NFA) expected(from []*State) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range from {
+		for l, to := range n.trans[s] {
+			if len(to) > 0 && !seen[l.name] {
+				seen[l.name] = true
+				out = append(out, l.name)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// names возвращает имена состояний в лексикографическом порядке
+func names(states []*State) []string {
+	out := make([]string, len(states))
+	for i, s := range states {
+		out[i] = s.name
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,68 @@
+package nfa_test
+
+import (
+	"nfa"
+	"reflect"
+	"testing"
+)
+
+// endsWithAB строит НКА цепочек над {a, b}, оканчивающихся на ab
+func endsWithAB() *nfa.NFA {
+	automata := nfa.NewNFA(3)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.AddLetter("c")
+	automata.SetTransition("s0", "s0", "a")
+	automata.SetTransition("s0", "s0", "b")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s1", "s2", "b")
+	automata.SetStartState("s0")
+	automata.SetEndState("s2")
+	return automata
+}
+
+func TestExplain(t *testing.T) {
+	automata := endsWithAB()
+
+	got := automata.Explain("bab")
+	if !got.Accepted || !reflect.DeepEqual(got.States, []string{"s0", "s2"}) {
+		t.Errorf("Explain(bab) = %+v", got)
+	}
+
+	got = automata.Explain("aбc")
+	want := nfa.Result{Offset: 1, RuneOffset: 1, States: []string{"s0", "s1"}, Symbol: "б", Expected: []string{"a", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Explain(aбc) = %+v\nожидалось %+v", got, want)
+	}
+	if s, w := got.String(), `смещение 1 (руна 1): из состояний {s0,s1} нет перехода по "б": допустимы "a" "b"`; s != w {
+		t.Errorf("String() = %q, ожидалось %q", s, w)
+	}
+
+	got = automata.Explain("aba")
+	if got.Accepted || !got.NonTerminal || !reflect.DeepEqual(got.States, []string{"s0", "s1"}) || got.Offset != 3 {
+		t.Errorf("Explain(aba) = %+v", got)
+	}
+
+	if !nfa.NewNFA(1).Explain("a").NoStart {
+		t.Errorf("без начального состояния ожидалось NoStart")
+	}
+}
+
+func TestExplainAgreesWithAccepts(t *testing.T) {
+	automata := endsWithAB()
+	words, level := []string{""}, []string{""}
+	for k := 0; k < 4; k++ {
+		var next []string
+		for _, w := range level {
+			for _, l := range "abc" {
+				next = append(next, w+string(l))
+			}
+		}
+		words, level = append(words, next...), next
+	}
+	for _, w := range words {
+		if automata.Explain(w).Accepted != automata.Accepts(w) {
+			t.Errorf("Explain(%q) расходится с Accepts", w)
+		}
+	}
+}
@@ -40,11 +40,12 @@ func (l *Letter) String() string {
 
 // NFA представляет недетерминированный конечный автомат
 type NFA struct {
-	states  map[*State]bool                 // множество состояний НКА
-	letters map[*Letter]bool                // множество символов алфавита НКА
-	trans   map[*State]map[*Letter][]*State // функция переходов НКА
-	start   *State                          // начальное состояние НКА
-	current []*State                        // текущее множество состояний НКА
+	states   map[*State]bool                 // множество состояний НКА
+	letters  map[*Letter]bool                // множество символов алфавита НКА
+	trans    map[*State]map[*Letter][]*State // функция переходов НКА
+	start    *State                          // начальное состояние НКА
+	current  []*State                        // текущее множество состояний НКА
+	observer Observer                        // наблюдатель за выполнением или nil
 }
 
 // NewNFA создает новый НКА
@@ -72,22 +73,33 @@ func NewNFA(statesCount int) *NFA {
 // AddState добавляет новое состояние в НКА с заданным именем и флагом заключительности
 // Возвращает указатель на добавленное состояние или nil, если такое имя уже существует
 func (n *NFA) AddState(name string, term bool) *State {
-	for s := range n.states {
-		if s.name == name {
-			return nil // имя уже занято
-		}
+	state, _ := n.AddStateE(name, term)
+	return state
+}
+
+// AddStateE добавляет новое состояние в НКА с заданным именем и флагом заключительности
+// Возвращает ошибку ErrDuplicateState, если такое имя уже существует
+func (n *NFA) AddStateE(name string, term bool) (*State, error) {
+	if n.FindStateByName(name) != nil {
+		return nil, &StateError{Op: "AddState", Name: name, Err: ErrDuplicateState} // имя уже занято
 	}
 	state := NewState(name, term)
 	n.states[state] = true
 	n.trans[state] = make(map[*Letter][]*State)
-	return state
+	return state, nil
 }
 
 // RemoveState удаляет заданное состояние из НКА и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого состояния не существует
 func (n *NFA) RemoveState(state *State) bool {
+	return n.RemoveStateE(state) == nil
+}
+
+// RemoveStateE удаляет заданное состояние из НКА и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownState, если такого состояния не существует
+func (n *NFA) RemoveStateE(state *State) error {
 	if _, ok := n.states[state]; !ok {
-		return false // такого состояния нет в НКА
+		return &StateError{Op: "RemoveState", Name: stateName(state), Err: ErrUnknownState} // такого состояния нет в НКА
 	}
 	delete(n.states, state)
 	delete(n.trans, state)
@@ -110,52 +122,69 @@ func (n *NFA) RemoveState(state *State) bool {
 			i--
 		}
 	}
-	return true
+	return nil
 }
 
 // AddLetter добавляет новый символ в алфавит НКА с заданным именем
 // Возвращает указатель на добавленный символ или nil, если такое имя уже существует
 func (n *NFA) AddLetter(name string) *Letter {
-	for l := range n.letters {
-		if l.name == name {
-			return nil // имя уже занято
-		}
+	letter, _ := n.AddLetterE(name)
+	return letter
+}
+
+// AddLetterE добавляет новый символ в алфавит НКА с заданным именем
+// Возвращает ошибку ErrDuplicateLetter, если такое имя уже существует
+func (n *NFA) AddLetterE(name string) (*Letter, error) {
+	if n.FindLetterByName(name) != nil {
+		return nil, &LetterError{Op: "AddLetter", Name: name, Err: ErrDuplicateLetter} // имя уже занято
 	}
 	letter := NewLetter(name)
 	n.letters[letter] = true
-	return letter
+	return letter, nil
 }
 
 // RemoveLetter удаляет заданный символ из алфавита НКА и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого символа не существует
 func (n *NFA) RemoveLetter(letter *Letter) bool {
+	return n.RemoveLetterE(letter) == nil
+}
+
+// RemoveLetterE удаляет заданный символ из алфавита НКА и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownLetter, если такого символа не существует
+func (n *NFA) RemoveLetterE(letter *Letter) error {
 	if _, ok := n.letters[letter]; !ok {
-		return false // такого символа нет в алфавите НКА
+		return &LetterError{Op: "RemoveLetter", Name: letterName(letter), Err: ErrUnknownLetter} // такого символа нет в алфавите НКА
 	}
 	delete(n.letters, letter)
 	for _, m := range n.trans {
 		delete(m, letter)
 	}
-	return true
+	return nil
 }
 
-// AddTransition добавляет переход из заданного исходного состояния в заданное конечное состояние по заданному символу
+// SetTransition добавляет переход из заданного исходного состояния в заданное конечное состояние по заданному символу
 // Возвращает true, если переход добавлен успешно, или false, если какой-то из параметров не принадлежит НКА
 func (n *NFA) SetTransition(fromName, toName, letterBy string) bool {
-	by := n.FindLetterByName(letterBy)
+	return n.SetTransitionE(fromName, toName, letterBy) == nil
+}
+
+// SetTransitionE добавляет переход из заданного исходного состояния в заданное конечное состояние по заданному символу
+// Возвращает ошибку ErrUnknownState или ErrUnknownLetter, если какой-то из параметров не принадлежит НКА
+func (n *NFA) SetTransitionE(fromName, toName, letterBy string) error {
 	from := n.FindStateByName(fromName)
-	to := n.FindStateByName(toName)
-	if _, ok := n.states[from]; !ok {
-		return false // исходное состояние не принадлежит НКА
+	if from == nil {
+		return &StateError{Op: "SetTransition", Name: fromName, Err: ErrUnknownState} // исходное состояние не принадлежит НКА
 	}
-	if _, ok := n.states[to]; !ok {
-		return false // конечное состояние не принадлежит НКА
+	to := n.FindStateByName(toName)
+	if to == nil {
+		return &StateError{Op: "SetTransition", Name: toName, Err: ErrUnknownState} // конечное состояние не принадлежит НКА
 	}
-	if _, ok := n.letters[by]; !ok {
-		return false // символ не принадлежит алфавиту НКА
+	by := n.FindLetterByName(letterBy)
+	if by == nil {
+		return &LetterError{Op: "SetTransition", Name: letterBy, Err: ErrUnknownLetter} // символ не принадлежит алфавиту НКА
 	}
 	n.trans[from][by] = append(n.trans[from][by], to)
-	return true
+	return nil
 }
 
 // FindLetterByName возвращает ссылку на букву алфавита по её имени
@@ -195,26 +224,69 @@ func (n *NFA) RemoveTransition(from, to *State, by *Letter) bool {
 	return false
 }
 
+// RemoveTransitionE удаляет переход из заданного исходного состояния в заданное конечное состояние по заданному символу.
+// Переходы по этому символу в другие состояния сохраняются
+// Возвращает ошибку ErrUnknownState, ErrUnknownLetter или ErrUnknownTransition, если перехода не существует
+func (n *NFA) RemoveTransitionE(from, to *State, by *Letter) error {
+	if _, ok := n.states[from]; !ok {
+		return &StateError{Op: "RemoveTransition", Name: stateName(from), Err: ErrUnknownState} // исходное состояние не принадлежит НКА
+	}
+	if _, ok := n.states[to]; !ok {
+		return &StateError{Op: "RemoveTransition", Name: stateName(to), Err: ErrUnknownState} // конечное состояние не принадлежит НКА
+	}
+	if _, ok := n.letters[by]; !ok {
+		return &LetterError{Op: "RemoveTransition", Name: letterName(by), Err: ErrUnknownLetter} // символ не принадлежит алфавиту НКА
+	}
+	targets := n.trans[from][by]
+	kept := make([]*State, 0, len(targets))
+	for _, s := range targets {
+		if s != to {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == len(targets) {
+		return &TransitionError{Op: "RemoveTransition", From: from.name, To: to.name, Letter: by.name, Err: ErrUnknownTransition} // перехода не существует
+	}
+	if len(kept) == 0 {
+		delete(n.trans[from], by)
+	} else {
+		n.trans[from][by] = kept // удалить переход только в заданное состояние
+	}
+	return nil
+}
+
 // SetStartState устанавливает начальное состояние НКА
 // Возвращает true, если состояние установлено успешно, или false, если заданное состояние не принадлежит НКА
 func (n *NFA) SetStartState(name string) bool {
+	return n.SetStartStateE(name) == nil
+}
+
+// SetStartStateE устанавливает начальное состояние НКА
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит НКА
+func (n *NFA) SetStartStateE(name string) error {
 	state := n.FindStateByName(name)
-	if _, ok := n.states[state]; !ok {
-		return false
+	if state == nil {
+		return &StateError{Op: "SetStartState", Name: name, Err: ErrUnknownState}
 	}
 	n.start = state
 	n.current = []*State{state}
-	return true
+	return nil
 }
 
 // SetEndState устанавливает состояние как конечное
 func (n *NFA) SetEndState(name string) bool {
+	return n.SetEndStateE(name) == nil
+}
+
+// SetEndStateE устанавливает состояние как конечное
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит НКА
+func (n *NFA) SetEndStateE(name string) error {
 	s := n.FindStateByName(name)
 	if s == nil {
-		return false
+		return &StateError{Op: "SetEndState", Name: name, Err: ErrUnknownState}
 	}
 	s.term = true
-	return true
+	return nil
 }
 
 // GetStartState возвращает начальное состояние НКА или nil, если оно не установлено
@@ -225,7 +297,7 @@ func (n *NFA) GetStartState() *State {
 // IsEndState возвращает true если текущее состояние автомата заключительное
 func (n *NFA) IsEndState() bool {
 	for _, state := range n.current {
-		if state.IsTerminal() {
+		if state != nil && state.IsTerminal() {
 			return true
 		}
 	}
@@ -239,16 +311,30 @@ func (n *NFA) GetCurrentStates() []*State {
 
 // ResetCurrentStates сбрасывает текущее множество состояний НКА в начальное состояние или nil, если оно не установлено
 func (n *NFA) ResetCurrentStates() {
-	n.current = []*State{n.start}
+	n.current = nil
+	if n.start != nil {
+		n.current = []*State{n.start}
+	}
 }
 
 // Transition выполняет переход из текущего множества состояний в другое по заданному символу и возвращает новое текущее множество состояний
 func (n *NFA) Transition(by *Letter) []*State {
+	next, _ := n.TransitionE(by)
+	return next
+}
+
+// TransitionE выполняет переход из текущего множества состояний в другое по заданному символу и возвращает новое текущее множество состояний
+// Возвращает ошибку ErrNoStartState, если начальное состояние не установлено, или ErrUnknownLetter,
+// если символ не принадлежит НКА. Если текущее множество уже пусто, возвращает nil без ошибки
+func (n *NFA) TransitionE(by *Letter) ([]*State, error) {
+	if n.start == nil {
+		return nil, ErrNoStartState
+	}
 	if len(n.current) == 0 {
-		return nil
+		return nil, nil
 	}
 	if _, ok := n.letters[by]; !ok {
-		return nil
+		return nil, &LetterError{Op: "Transition", Name: letterName(by), Err: ErrUnknownLetter}
 	}
 	next := make(map[*State]bool)
 	for _, s := range n.current {
@@ -258,11 +344,15 @@ func (n *NFA) Transition(by *Letter) []*State {
 			}
 		}
 	}
+	from := n.current
 	n.current = make([]*State, 0, len(next)) // обновить текущее множество
 	for s := range next {
 		n.current = append(n.current, s)
 	}
-	return n.current
+	if n.observer != nil && len(n.current) > 0 {
+		n.observer.OnTransition(from, by, n.current) // переход в пустое множество сообщается отказом
+	}
+	return n.current, nil
 }
 
 // Accepts проверяет строку на принадлежность языку ДКА
@@ -270,14 +360,21 @@ func (n *NFA) Transition(by *Letter) []*State {
 func (n *NFA) Accepts(s string) bool {
 	n.ResetCurrentStates()
 	for _, r := range s {
+		from := n.current
 		var l *Letter
 		l = n.FindLetterByName(string(r))
 		if l == nil {
-			return false
+			return n.reject(from, NewLetter(string(r)))
 		}
-		if n.Transition(l) == nil {
-			return false
+		if len(n.Transition(l)) == 0 {
+			return n.reject(from, l)
 		}
 	}
-	return n.IsEndState()
+	if !n.IsEndState() {
+		return n.reject(n.current, nil)
+	}
+	if n.observer != nil {
+		n.observer.OnAccept(n.current)
+	}
+	return true
 }
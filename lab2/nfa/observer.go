@@ -0,0 +1,120 @@
+package nfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Observer получает уведомления о ходе работы НКА. OnTransition вызывается после каждого
+// перехода, OnAccept и OnReject — по окончании проверки строки методом Accepts
+type Observer interface {
+	// OnTransition сообщает о переходе из множества состояний from во множество to по символу by
+	OnTransition(from []*State, by *Letter, to []*State)
+	// OnReject сообщает, что строка отвергнута во множестве состояний at: по символу by
+	// нет перехода ни из одного состояния, а если by равен nil — строка прочитана целиком,
+	// но среди at нет заключительных. Если начальное состояние не установлено, at пусто
+	OnReject(at []*State, by *Letter)
+	// OnAccept сообщает, что строка принята; at содержит хотя бы одно заключительное состояние
+	OnAccept(at []*State)
+}
+
+// SetObserver подключает наблюдатель к НКА; значение nil отключает наблюдение
+func (n *NFA) SetObserver(o Observer) {
+	n.observer = o
+}
+
+// reject сообщает наблюдателю об отказе и возвращает false
+func (n *NFA) reject(at []*State, by *Letter) bool {
+	if n.observer != nil {
+		n.observer.OnReject(at, by)
+	}
+	return false
+}
+
+// TraceStep — один переход записанного выполнения
+type TraceStep struct {
+	From   []*State // исходное множество состояний
+	Letter *Letter  // символ перехода
+	To     []*State // множество состояний после перехода
+}
+
+// TraceRecorder — наблюдатель, записывающий выполнение целиком: переходы, последнее множество
+// состояний и итог проверки. Запись новой проверки после завершения предыдущей начинается заново
+type TraceRecorder struct {
+	Steps    []TraceStep // выполненные переходы по порядку
+	Final    []*State    // множество состояний, в котором закончилась проверка
+	Blocked  *Letter     // символ, по которому не оказалось перехода, или nil
+	Done     bool        // проверка завершена
+	Accepted bool        // строка принята
+}
+
+// OnTransition записывает переход
+func (r *TraceRecorder) OnTransition(from []*State, by *Letter, to []*State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Steps = append(r.Steps, TraceStep{From: from, Letter: by, To: to})
+}
+
+// OnReject записывает отказ
+func (r *TraceRecorder) OnReject(at []*State, by *Letter) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Blocked, r.Done = at, by, true
+}
+
+// OnAccept записывает принятие строки
+func (r *TraceRecorder) OnAccept(at []*State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Done, r.Accepted = at, true, true
+}
+
+// Reset очищает запись
+func (r *TraceRecorder) Reset() {
+	*r = TraceRecorder{}
+}
+
+// Path возвращает путь по множествам состояний: исходное множество первого перехода
+// и множества после каждого перехода. Если переходов не было, путь состоит из последнего множества
+func (r *TraceRecorder) Path() [][]*State {
+	if len(r.Steps) == 0 {
+		if r.Final == nil {
+			return nil
+		}
+		return [][]*State{r.Final}
+	}
+	path := [][]*State{r.Steps[0].From}
+	for _, st := range r.Steps {
+		path = append(path, st.To)
+	}
+	return path
+}
+
+// String возвращает запись выполнения вида {s0} -a-> {s0,s1} -b-> {s0,s2}: принята
+func (r *TraceRecorder) String() string {
+	var b strings.Builder
+	for i, set := range r.Path() {
+		if i > 0 {
+			fmt.Fprintf(&b, " -%s-> ", r.Steps[i-1].Letter)
+		}
+		b.WriteString("{" + strings.Join(names(set), ",") + "}")
+	}
+	verdict := ""
+	switch {
+	case !r.Done:
+	case r.Accepted:
+		verdict = "принята"
+	case r.Blocked != nil:
+		verdict = fmt.Sprintf("нет перехода по %q", r.Blocked.name)
+	default:
+		verdict = "нет заключительных состояний"
+	}
+	if verdict != "" && b.Len() > 0 {
+		b.WriteString(": ")
+	}
+	b.WriteString(verdict)
+	return b.String()
+}
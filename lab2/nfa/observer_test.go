@@ -0,0 +1,41 @@
+package nfa_test
+
+import (
+	"nfa"
+	"testing"
+)
+
+func TestTraceRecorder(t *testing.T) {
+	automata := endsWithAB()
+	trace := &nfa.TraceRecorder{}
+	automata.SetObserver(trace)
+
+	if !automata.Accepts("bab") {
+		t.Fatalf("строка bab должна быть принята")
+	}
+	if got, want := trace.String(), "{s0} -b-> {s0} -a-> {s0,s1} -b-> {s0,s2}: принята"; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+	if path := trace.Path(); len(path) != 4 || len(path[3]) != 2 {
+		t.Errorf("Path() = %v", path)
+	}
+
+	automata.Accepts("ac")
+	if got, want := trace.String(), `{s0} -a-> {s0,s1}: нет перехода по "c"`; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+	automata.Accepts("ax")
+	if trace.Blocked == nil || trace.Blocked.String() != "x" || trace.Accepted {
+		t.Errorf("символ вне алфавита записан неверно: %s", trace)
+	}
+	automata.Accepts("ba")
+	if got, want := trace.String(), "{s0} -b-> {s0} -a-> {s0,s1}: нет заключительных состояний"; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+
+	automata.SetObserver(nil)
+	automata.Accepts("ab")
+	if trace.Accepted {
+		t.Errorf("отключённый наблюдатель получил уведомление")
+	}
+}
@@ -0,0 +1,166 @@
+package nfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiagnosticKind — вид замечания о структуре автомата
+type DiagnosticKind int
+
+const (
+	NoStartState     DiagnosticKind = iota // начальное состояние не установлено
+	NoTerminalStates                       // нет ни одного заключительного состояния
+	UnreachableState                       // состояние недостижимо из начального
+	DeadState                              // из состояния недостижимо ни одно заключительное
+	UnusedLetter                           // символ не используется ни в одном переходе
+	IncompleteState                        // из состояния определены переходы не по всем символам
+)
+
+// String возвращает строковое представление вида замечания
+func (k DiagnosticKind) String() string {
+	switch k {
+	case NoStartState:
+		return "нет начального состояния"
+	case NoTerminalStates:
+		return "нет заключительных состояний"
+	case UnreachableState:
+		return "недостижимое состояние"
+	case DeadState:
+		return "тупиковое состояние"
+	case UnusedLetter:
+		return "неиспользуемый символ"
+	case IncompleteState:
+		return "неполная строка переходов"
+	}
+	return fmt.Sprintf("DiagnosticKind(%d)", int(k))
+}
+
+// Diagnostic — замечание о структуре автомата
+type Diagnostic struct {
+	Kind    DiagnosticKind // вид замечания
+	State   string         // имя состояния, к которому относится замечание
+	Letter  string         // имя символа для UnusedLetter
+	Missing []string       // символы без перехода для IncompleteState
+}
+
+// String возвращает строковое представление замечания
+func (d Diagnostic) String() string {
+	switch d.Kind {
+	case UnreachableState, DeadState:
+		return fmt.Sprintf("%v: %s", d.Kind, d.State)
+	case UnusedLetter:
+		return fmt.Sprintf("%v: %q", d.Kind, d.Letter)
+	case IncompleteState:
+		return fmt.Sprintf("%v: %s, нет переходов по %s", d.Kind, d.State, strings.Join(d.Missing, " "))
+	}
+	return d.Kind.String()
+}
+
+// Validate проверяет структуру НКА и возвращает список замечаний: отсутствие начального
+// или заключительных состояний, недостижимые и тупиковые состояния, неиспользуемые символы
+// и состояния, из которых определены переходы не по всем символам. Пустой список означает,
+// что замечаний нет. Замечания упорядочены по виду, а внутри вида — по именам
+func (n *NFA) Validate() []Diagnostic {
+	states := make([]*State, 0, len(n.states))
+	for s := range n.states {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].name < states[j].name })
+	letters := make([]*Letter, 0, len(n.letters))
+	for l := range n.letters {
+		letters = append(letters, l)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i].name < letters[j].name })
+
+	var out []Diagnostic
+	if n.start == nil {
+		out = append(out, Diagnostic{Kind: NoStartState})
+	}
+	anyTerm := false
+	for _, s := range states {
+		anyTerm = anyTerm || s.term
+	}
+	if !anyTerm {
+		out = append(out, Diagnostic{Kind: NoTerminalStates})
+	}
+
+	if n.start != nil {
+		reach := n.closure([]*State{n.start}, func(s *State) []*State {
+			var next []*State
+			for _, to := range n.trans[s] {
+				next = append(next, to...)
+			}
+			return next
+		})
+		for _, s := range states {
+			if !reach[s] {
+				out = append(out, Diagnostic{Kind: UnreachableState, State: s.name})
+			}
+		}
+	}
+	if anyTerm {
+		rev := make(map[*State][]*State)
+		var terms []*State
+		for _, s := range states {
+			if s.term {
+				terms = append(terms, s)
+			}
+			for _, to := range n.trans[s] {
+				for _, t := range to {
+					rev[t] = append(rev[t], s)
+				}
+			}
+		}
+		live := n.closure(terms, func(s *State) []*State { return rev[s] })
+		for _, s := range states {
+			if !live[s] {
+				out = append(out, Diagnostic{Kind: DeadState, State: s.name})
+			}
+		}
+	}
+
+	for _, l := range letters {
+		used := false
+		for _, s := range states {
+			used = used || len(n.trans[s][l]) > 0
+		}
+		if !used {
+			out = append(out, Diagnostic{Kind: UnusedLetter, Letter: l.name})
+		}
+	}
+
+	for _, s := range states {
+		var missing []string
+		for _, l := range letters {
+			if len(n.trans[s][l]) == 0 {
+				missing = append(missing, l.name)
+			}
+		}
+		if len(missing) > 0 {
+			out = append(out, Diagnostic{Kind: IncompleteState, State: s.name, Missing: missing})
+		}
+	}
+	return out
+}
+
+// closure возвращает множество состояний, достижимых из заданных по отношению next
+func (n *NFA) closure(from []*State, next func(*State) []*State) map[*State]bool {
+	seen := make(map[*State]bool)
+	queue := append([]*State(nil), from...)
+	for _, s := range from {
+		seen[s] = true
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, t := range next(s) {
+			if !seen[t] {
+				seen[t] = true
+				queue = append(queue, t)
+			}
+		}
+	}
+	return seen
+}
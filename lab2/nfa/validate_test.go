@@ -0,0 +1,49 @@
+package nfa_test
+
+import (
+	"nfa"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	automata := nfa.NewNFA(4)
+	automata.AddLetter("a")
+	automata.AddLetter("b")
+	automata.SetTransition("s0", "s0", "a")
+	automata.SetTransition("s0", "s1", "a")
+	automata.SetTransition("s0", "s2", "b")
+	automata.SetTransition("s3", "s1", "b")
+	automata.SetStartState("s0")
+	automata.SetEndState("s1")
+
+	got := automata.Validate()
+	want := []nfa.Diagnostic{
+		{Kind: nfa.UnreachableState, State: "s3"},
+		{Kind: nfa.DeadState, State: "s2"},
+		{Kind: nfa.IncompleteState, State: "s1", Missing: []string{"a", "b"}},
+		{Kind: nfa.IncompleteState, State: "s2", Missing: []string{"a", "b"}},
+		{Kind: nfa.IncompleteState, State: "s3", Missing: []string{"a"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v\nожидалось %v", got, want)
+	}
+}
+
+func TestValidateNoStart(t *testing.T) {
+	automata := nfa.NewNFA(1)
+	automata.AddLetter("a")
+	if automata.Accepts("") || automata.Accepts("a") {
+		t.Errorf("НКА без начального состояния не принимает цепочек")
+	}
+	got := automata.Validate()
+	want := []nfa.Diagnostic{
+		{Kind: nfa.NoStartState},
+		{Kind: nfa.NoTerminalStates},
+		{Kind: nfa.UnusedLetter, Letter: "a"},
+		{Kind: nfa.IncompleteState, State: "s0", Missing: []string{"a"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v\nожидалось %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package pda
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ошибки, которые возвращают методы КАМП с суффиксом E.
+// Проверяются через errors.Is; конкретное имя содержат StateError, LetterError и TransitionError
+var (
+	ErrUnknownState      = errors.New("состояние не принадлежит КАМП")
+	ErrUnknownLetter     = errors.New("символ не принадлежит алфавиту КАМП")
+	ErrDuplicateState    = errors.New("состояние с таким именем уже существует")
+	ErrDuplicateLetter   = errors.New("символ с таким именем уже существует")
+	ErrUnknownTransition = errors.New("перехода не существует")
+	ErrNoStartState      = errors.New("начальное состояние не установлено")
+)
+
+// StateError описывает ошибку операции над состоянием КАМП
+type StateError struct {
+	Op   string // имя операции, например "SetTransition"
+	Name string // имя состояния
+	Err  error  // ErrUnknownState или ErrDuplicateState
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("pda: %s: состояние %q: %v", e.Op, e.Name, e.Err)
+}
+
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
+
+// LetterError описывает ошибку операции над символом алфавита КАМП
+type LetterError struct {
+	Op   string // имя операции, например "SetTransition"
+	Name string // имя символа
+	Err  error  // ErrUnknownLetter или ErrDuplicateLetter
+}
+
+func (e *LetterError) Error() string {
+	return fmt.Sprintf("pda: %s: символ %q: %v", e.Op, e.Name, e.Err)
+}
+
+func (e *LetterError) Unwrap() error {
+	return e.Err
+}
+
+// TransitionError описывает ошибку операции над переходом КАМП
+type TransitionError struct {
+	Op     string // имя операции, например "RemoveTransition"
+	From   string // имя исходного состояния
+	Letter string // имя символа перехода
+	Err    error  // ErrUnknownTransition
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("pda: %s: переход из %q по %q: %v", e.Op, e.From, e.Letter, e.Err)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// stateName возвращает имя состояния для сообщения об ошибке
+func stateName(s *State) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return s.name
+}
+
+// letterName возвращает имя символа для сообщения об ошибке
+func letterName(l *Letter) string {
+	if l == nil {
+		return "<nil>"
+	}
+	return l.name
+}
@@ -0,0 +1,33 @@
+package pda_test
+
+import (
+	"errors"
+	"pda"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	automata := pda.NewPDA(1)
+	automata.AddLetter("(")
+
+	if _, err := automata.AddStateE("s0", true); !errors.Is(err, pda.ErrDuplicateState) {
+		t.Errorf("AddStateE: ожидалась ErrDuplicateState, получено %v", err)
+	}
+	var le *pda.LetterError
+	if err := automata.SetTransitionE("s0", "s0", ")"); !errors.As(err, &le) || le.Name != ")" {
+		t.Errorf("SetTransitionE: ожидалась LetterError для ), получено %v", err)
+	}
+	var se *pda.StateError
+	if err := automata.SetStartStateE("s1"); !errors.As(err, &se) || se.Name != "s1" {
+		t.Errorf("SetStartStateE: ожидалась StateError для s1, получено %v", err)
+	}
+	if _, err := automata.TransitionE(automata.FindLetterByName("(")); !errors.Is(err, pda.ErrNoStartState) {
+		t.Errorf("TransitionE без начального состояния: получено %v", err)
+	}
+
+	automata.SetStartState("s0")
+	var te *pda.TransitionError
+	if _, err := automata.TransitionE(automata.FindLetterByName("(")); !errors.As(err, &te) || te.From != "s0" {
+		t.Errorf("TransitionE без перехода: ожидалась TransitionError, получено %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package pda
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Observer получает уведомления о ходе работы КАМП. OnTransition вызывается после каждого
+// перехода, OnAccept и OnReject — по окончании проверки строки методом Accepts.
+// Содержимое стека в момент уведомления возвращает метод Stack
+type Observer interface {
+	// OnTransition сообщает о переходе из from в to по символу by
+	OnTransition(from *State, by *Letter, to *State)
+	// OnReject сообщает, что строка отвергнута в состоянии at: по символу by нет перехода
+	// или закрывающая скобка не соответствует вершине стека, а если by равен nil — строка
+	// прочитана целиком, но at не заключительное или стек не пуст.
+	// Если начальное состояние не установлено, at равно nil
+	OnReject(at *State, by *Letter)
+	// OnAccept сообщает, что строка принята в заключительном состоянии at с пустым стеком
+	OnAccept(at *State)
+}
+
+// SetObserver подключает наблюдатель к КАМП; значение nil отключает наблюдение
+func (p *PDA) SetObserver(o Observer) {
+	p.observer = o
+}
+
+// Stack возвращает копию содержимого стека от дна к вершине
+func (p *PDA) Stack() []string {
+	out := make([]string, 0, p.stack.Len())
+	for e := p.stack.Front(); e != nil; e = e.Next() {
+		if str, ok := e.Value.(string); ok {
+			out = append(out, str)
+		}
+	}
+	return out
+}
+
+// reject сообщает наблюдателю об отказе и возвращает false
+func (p *PDA) reject(at *State, by *Letter) bool {
+	if p.observer != nil {
+		p.observer.OnReject(at, by)
+	}
+	return false
+}
+
+// TraceStep — один переход записанного выполнения
+type TraceStep struct {
+	From   *State  // исходное состояние
+	Letter *Letter // символ перехода
+	To     *State  // состояние перехода
+}
+
+// TraceRecorder — наблюдатель, записывающий выполнение целиком: переходы, последнее состояние
+// и итог проверки. Запись новой проверки после завершения предыдущей начинается заново
+type TraceRecorder struct {
+	Steps    []TraceStep // выполненные переходы по порядку
+	Final    *State      // состояние, в котором закончилась проверка
+	Blocked  *Letter     // символ, на котором проверка остановилась, или nil
+	Done     bool        // проверка завершена
+	Accepted bool        // строка принята
+}
+
+// OnTransition записывает переход
+func (r *TraceRecorder) OnTransition(from *State, by *Letter, to *State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Steps = append(r.Steps, TraceStep{From: from, Letter: by, To: to})
+}
+
+// OnReject записывает отказ
+func (r *TraceRecorder) OnReject(at *State, by *Letter) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Blocked, r.Done = at, by, true
+}
+
+// OnAccept записывает принятие строки
+func (r *TraceRecorder) OnAccept(at *State) {
+	if r.Done {
+		r.Reset()
+	}
+	r.Final, r.Done, r.Accepted = at, true, true
+}
+
+// Reset очищает запись
+func (r *TraceRecorder) Reset() {
+	*r = TraceRecorder{}
+}
+
+// Path возвращает путь по состояниям: исходное состояние первого перехода и состояния
+// после каждого перехода. Если переходов не было, путь состоит из последнего состояния
+func (r *TraceRecorder) Path() []*State {
+	if len(r.Steps) == 0 {
+		if r.Final == nil {
+			return nil
+		}
+		return []*State{r.Final}
+	}
+	path := []*State{r.Steps[0].From}
+	for _, st := range r.Steps {
+		path = append(path, st.To)
+	}
+	return path
+}
+
+// String возвращает запись выполнения вида s0 -(-> s0 -)-> s0: принята
+func (r *TraceRecorder) String() string {
+	var b strings.Builder
+	for i, s := range r.Path() {
+		if i > 0 {
+			fmt.Fprintf(&b, " -%s-> ", letterName(r.Steps[i-1].Letter))
+		}
+		b.WriteString(stateName(s))
+	}
+	verdict := ""
+	switch {
+	case !r.Done:
+	case r.Accepted:
+		verdict = "принята"
+	case r.Final == nil:
+		verdict = "начальное состояние не установлено"
+	case r.Blocked != nil:
+		verdict = fmt.Sprintf("остановка на %q", r.Blocked.name)
+	default:
+		verdict = "состояние не заключительное или стек не пуст"
+	}
+	if verdict != "" && b.Len() > 0 {
+		b.WriteString(": ")
+	}
+	b.WriteString(verdict)
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package pda_test
+
+import (
+	"pda"
+	"reflect"
+	"testing"
+)
+
+// bracesPDA строит КАМП из одного состояния, читающий скобки трёх видов
+func bracesPDA() *pda.PDA {
+	automata := pda.NewPDA(1)
+	for _, l := range []string{"(", ")", "{", "}", "[", "]"} {
+		automata.AddLetter(l)
+		automata.SetTransition("s0", "s0", l)
+	}
+	automata.SetStartState("s0")
+	automata.SetEndState("s0")
+	return automata
+}
+
+// stackObserver запоминает содержимое стека после каждого перехода
+type stackObserver struct {
+	automata *pda.PDA
+	stacks   [][]string
+	accepted bool
+}
+
+func (o *stackObserver) OnTransition(from *pda.State, by *pda.Letter, to *pda.State) {
+	o.stacks = append(o.stacks, o.automata.Stack())
+}
+func (o *stackObserver) OnReject(at *pda.State, by *pda.Letter) {}
+func (o *stackObserver) OnAccept(at *pda.State)                 { o.accepted = true }
+
+func TestTraceRecorder(t *testing.T) {
+	automata := bracesPDA()
+	trace := &pda.TraceRecorder{}
+	automata.SetObserver(trace)
+
+	if !automata.Accepts("({})") {
+		t.Fatalf("строка ({}) должна быть принята")
+	}
+	if got, want := trace.String(), "s0 -(-> s0 -{-> s0 -}-> s0 -)-> s0: принята"; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+
+	automata.Accepts("(]")
+	if got, want := trace.String(), `s0 -(-> s0: остановка на "]"`; got != want {
+		t.Errorf("String() = %q\nожидалось %q", got, want)
+	}
+	automata.Accepts("((")
+	if trace.Blocked != nil || len(trace.Path()) != 3 || trace.Accepted {
+		t.Errorf("незакрытые скобки записаны неверно: %s", trace)
+	}
+}
+
+func TestObserverStack(t *testing.T) {
+	automata := bracesPDA()
+	o := &stackObserver{automata: automata}
+	automata.SetObserver(o)
+	automata.Accepts("([])")
+	want := [][]string{{")"}, {")", "]"}, {")"}, {}}
+	if !reflect.DeepEqual(o.stacks, want) || !o.accepted {
+		t.Errorf("стек по шагам %q, ожидалось %q", o.stacks, want)
+	}
+}
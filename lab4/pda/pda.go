@@ -30,12 +30,13 @@ func NewLetter(name string) *Letter {
 }
 
 type PDA struct {
-	states  map[*State]bool
-	letters map[*Letter]bool
-	trans   map[*State]map[*Letter]*State
-	start   *State
-	current *State
-	stack   *list.List
+	states   map[*State]bool
+	letters  map[*Letter]bool
+	trans    map[*State]map[*Letter]*State
+	start    *State
+	current  *State
+	stack    *list.List
+	observer Observer
 }
 
 func NewPDA(statesCount int) *PDA {
@@ -63,22 +64,33 @@ func NewPDA(statesCount int) *PDA {
 // AddState добавляет новое состояние в КАМП с заданным именем и флагом заключительности
 // Возвращает указатель на добавленное состояние или nil, если такое имя уже существует
 func (p *PDA) AddState(name string, term bool) *State {
-	for s := range p.states {
-		if s.name == name {
-			return nil // имя уже занято
-		}
+	state, _ := p.AddStateE(name, term)
+	return state
+}
+
+// AddStateE добавляет новое состояние в КАМП с заданным именем и флагом заключительности
+// Возвращает ошибку ErrDuplicateState, если такое имя уже существует
+func (p *PDA) AddStateE(name string, term bool) (*State, error) {
+	if p.FindStateByName(name) != nil {
+		return nil, &StateError{Op: "AddState", Name: name, Err: ErrDuplicateState} // имя уже занято
 	}
 	state := NewState(name, term)
 	p.states[state] = true
 	p.trans[state] = make(map[*Letter]*State)
-	return state
+	return state, nil
 }
 
 // RemoveState удаляет заданное состояние из КАМП и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого состояния не существует
 func (p *PDA) RemoveState(state *State) bool {
+	return p.RemoveStateE(state) == nil
+}
+
+// RemoveStateE удаляет заданное состояние из КАМП и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownState, если такого состояния не существует
+func (p *PDA) RemoveStateE(state *State) error {
 	if _, ok := p.states[state]; !ok {
-		return false // такого состояния нет в ДКА
+		return &StateError{Op: "RemoveState", Name: stateName(state), Err: ErrUnknownState} // такого состояния нет в КАМП
 	}
 	delete(p.states, state)
 	delete(p.trans, state)
@@ -95,33 +107,44 @@ func (p *PDA) RemoveState(state *State) bool {
 	if p.current == state {
 		p.current = nil
 	}
-	return true
+	return nil
 }
 
 // AddLetter добавляет новый символ в алфавит КАМП с заданным именем
 // Возвращает указатель на добавленный символ или nil, если такое имя уже существует
 func (p *PDA) AddLetter(name string) *Letter {
-	for l := range p.letters {
-		if l.name == name {
-			return nil // имя уже занято
-		}
+	letter, _ := p.AddLetterE(name)
+	return letter
+}
+
+// AddLetterE добавляет новый символ в алфавит КАМП с заданным именем
+// Возвращает ошибку ErrDuplicateLetter, если такое имя уже существует
+func (p *PDA) AddLetterE(name string) (*Letter, error) {
+	if p.FindLetterByName(name) != nil {
+		return nil, &LetterError{Op: "AddLetter", Name: name, Err: ErrDuplicateLetter} // имя уже занято
 	}
 	letter := NewLetter(name)
 	p.letters[letter] = true
-	return letter
+	return letter, nil
 }
 
 // RemoveLetter удаляет заданный символ из алфавита КАМП и все связанные с ним переходы
 // Возвращает true, если удаление прошло успешно, или false, если такого символа не существует
 func (p *PDA) RemoveLetter(letter *Letter) bool {
+	return p.RemoveLetterE(letter) == nil
+}
+
+// RemoveLetterE удаляет заданный символ из алфавита КАМП и все связанные с ним переходы
+// Возвращает ошибку ErrUnknownLetter, если такого символа не существует
+func (p *PDA) RemoveLetterE(letter *Letter) error {
 	if _, ok := p.letters[letter]; !ok {
-		return false // такого символа нет в алфавите ДКА
+		return &LetterError{Op: "RemoveLetter", Name: letterName(letter), Err: ErrUnknownLetter} // такого символа нет в алфавите КАМП
 	}
 	delete(p.letters, letter)
 	for _, m := range p.trans {
 		delete(m, letter) // удалить переход по удаляемому символу
 	}
-	return true
+	return nil
 }
 
 // FindLetterByName возвращает ссылку на букву алфавита по её имени
@@ -148,28 +171,41 @@ func (p *PDA) FindStateByName(name string) *State {
 // SetStartState устанавливает начальное состояние КАМП
 // Возвращает true, если состояние установлено успешно, или false, если заданное состояние не принадлежит КАМП
 func (p *PDA) SetStartState(name string) bool {
+	return p.SetStartStateE(name) == nil
+}
+
+// SetStartStateE устанавливает начальное состояние КАМП
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит КАМП
+func (p *PDA) SetStartStateE(name string) error {
 	s := p.FindStateByName(name)
 	if s == nil {
-		return false
+		return &StateError{Op: "SetStartState", Name: name, Err: ErrUnknownState}
 	}
 	p.start = s
 	p.current = s
-	return true
+	return nil
 }
 
 // SetEndState устанавливает состояние как конечное
 func (p *PDA) SetEndState(name string) bool {
+	return p.SetEndStateE(name) == nil
+}
+
+// SetEndStateE устанавливает состояние как конечное
+// Возвращает ошибку ErrUnknownState, если заданное состояние не принадлежит КАМП
+func (p *PDA) SetEndStateE(name string) error {
 	s := p.FindStateByName(name)
 	if s == nil {
-		return false
+		return &StateError{Op: "SetEndState", Name: name, Err: ErrUnknownState}
 	}
 	s.term = true
-	return true
+	return nil
 }
 
 // IsEndState проверка, является ли состояние конечным
+// Если текущее состояние не установлено, возвращает false
 func (p *PDA) IsEndState() bool {
-	return p.current.IsTerminal()
+	return p.current != nil && p.current.IsTerminal()
 }
 
 // GetCurrentState возвращает текущее состояние КАМП или nil, если оно не установлено
@@ -208,51 +244,73 @@ func (p *PDA) IsStackEmpty() bool {
 // SetTransition устанавливает переход из заданного исходного состояния в заданное конечное состояние по заданному символу
 // Возвращает true, если переход установлен успешно, или false, если какой-то из параметров не принадлежит КАМП
 func (p *PDA) SetTransition(fromName, toName, letterBy string) bool {
-	by := p.FindLetterByName(letterBy)
+	return p.SetTransitionE(fromName, toName, letterBy) == nil
+}
+
+// SetTransitionE устанавливает переход из заданного исходного состояния в заданное конечное состояние по заданному символу
+// Возвращает ошибку ErrUnknownState или ErrUnknownLetter, если какой-то из параметров не принадлежит КАМП
+func (p *PDA) SetTransitionE(fromName, toName, letterBy string) error {
 	from := p.FindStateByName(fromName)
-	to := p.FindStateByName(toName)
-	if _, ok := p.states[from]; !ok {
-		return false // исходное состояние не принадлежит ДКА
+	if from == nil {
+		return &StateError{Op: "SetTransition", Name: fromName, Err: ErrUnknownState} // исходное состояние не принадлежит КАМП
 	}
-	if _, ok := p.states[to]; !ok {
-		return false // конечное состояние не принадлежит ДКА
+	to := p.FindStateByName(toName)
+	if to == nil {
+		return &StateError{Op: "SetTransition", Name: toName, Err: ErrUnknownState} // конечное состояние не принадлежит КАМП
 	}
-	if _, ok := p.letters[by]; !ok {
-		return false // символ не принадлежит алфавиту ДКА
+	by := p.FindLetterByName(letterBy)
+	if by == nil {
+		return &LetterError{Op: "SetTransition", Name: letterBy, Err: ErrUnknownLetter} // символ не принадлежит алфавиту КАМП
 	}
 	p.trans[from][by] = to
-	return true
+	return nil
 }
 
 // RemoveTransition удаляет переход из заданного исходного состояния по заданному символу
 // Возвращает true, если переход удален успешно, или false, если какой-то из параметров не принадлежит КАМП или перехода не существует
 func (p *PDA) RemoveTransition(from *State, by *Letter) bool {
+	return p.RemoveTransitionE(from, by) == nil
+}
+
+// RemoveTransitionE удаляет переход из заданного исходного состояния по заданному символу
+// Возвращает ошибку ErrUnknownState, ErrUnknownLetter или ErrUnknownTransition
+func (p *PDA) RemoveTransitionE(from *State, by *Letter) error {
 	if _, ok := p.states[from]; !ok {
-		return false // исходное состояние не принадлежит ДКА
+		return &StateError{Op: "RemoveTransition", Name: stateName(from), Err: ErrUnknownState} // исходное состояние не принадлежит КАМП
 	}
 	if _, ok := p.letters[by]; !ok {
-		return false // символ не принадлежит алфавиту ДКА
+		return &LetterError{Op: "RemoveTransition", Name: letterName(by), Err: ErrUnknownLetter} // символ не принадлежит алфавиту КАМП
 	}
 	if _, ok := p.trans[from][by]; !ok {
-		return false // перехода не существует
+		return &TransitionError{Op: "RemoveTransition", From: from.name, Letter: by.name, Err: ErrUnknownTransition} // перехода не существует
 	}
 	delete(p.trans[from], by) // удалить переход
-	return true
+	return nil
 }
 
 // Transition выполняет переход из текущего состояния в другое по заданному символу и возвращает новое текущее состояние
 func (p *PDA) Transition(by *Letter) *State {
+	to, _ := p.TransitionE(by)
+	return to
+}
+
+// TransitionE выполняет переход из текущего состояния в другое по заданному символу и возвращает новое текущее состояние
+// Возвращает ошибку ErrNoStartState, ErrUnknownLetter или ErrUnknownTransition, если переход невозможен
+func (p *PDA) TransitionE(by *Letter) (*State, error) {
 	if p.current == nil {
-		return nil // текущее состояние не установлено
+		return nil, ErrNoStartState // текущее состояние не установлено
 	}
 	if _, ok := p.letters[by]; !ok {
-		return nil // символ не принадлежит алфавиту ДКА
+		return nil, &LetterError{Op: "Transition", Name: letterName(by), Err: ErrUnknownLetter} // символ не принадлежит алфавиту КАМП
 	}
 	if to, ok := p.trans[p.current][by]; ok {
+		if p.observer != nil {
+			p.observer.OnTransition(p.current, by, to)
+		}
 		p.current = to
-		return p.current
+		return p.current, nil
 	}
-	return nil
+	return nil, &TransitionError{Op: "Transition", From: p.current.name, Letter: by.name, Err: ErrUnknownTransition}
 }
 
 // Accepts проверяет строку на принадлежность языку КАМП
@@ -284,7 +342,7 @@ func (p *PDA) Accepts(s string) bool {
 
 		l := p.FindLetterByName(r)
 		if l == nil {
-			return false
+			return p.reject(p.current, NewLetter(r))
 		}
 
 		for _, b := range braces {
@@ -292,15 +350,22 @@ func (p *PDA) Accepts(s string) bool {
 				p.PushStack(b.close)
 			} else if b.close == r {
 				if p.PopStack() != r {
-					return false
+					return p.reject(p.current, l)
 				}
 			}
 		}
 
+		from := p.current
 		if p.Transition(l) == nil {
-			return false
+			return p.reject(from, l)
 		}
 	}
 
-	return p.IsEndState() && p.IsStackEmpty()
+	if !p.IsEndState() || !p.IsStackEmpty() {
+		return p.reject(p.current, nil)
+	}
+	if p.observer != nil {
+		p.observer.OnAccept(p.current)
+	}
+	return true
 }